@@ -1,10 +1,3 @@
-(function initCodeHighlight() {
-  const update = () => hljs.highlightAll();
-
-  update();
-  document.body.addEventListener("htmx:load", update);
-})();
-
 /**
  * Initializes the dark mode functionality.
  * This function sets up the event listeners and initial state for dark mode based on user preference or system settings.
@@ -70,3 +63,174 @@
 
   document.body.addEventListener("htmx:load", close);
 })();
+
+/**
+ * Initializes the search box.
+ * The search index generated at build time is fetched on first use and queried locally.
+ */
+(function initSearch() {
+  const input = document.getElementById("search-input");
+  const results = document.getElementById("search-results");
+  const webroot = document.body.dataset.webroot || "";
+  const maxResults = 10;
+
+  let index = null;
+
+  const loadIndex = async () => {
+    if (!index) {
+      const res = await fetch(`${webroot}/search.json`);
+      index = await res.json();
+    }
+    return index;
+  };
+
+  const escape = (text) => {
+    const div = document.createElement("div");
+    div.textContent = text;
+    return div.innerHTML;
+  };
+
+  const count = (text, term) => {
+    let total = 0;
+    let pos = text.indexOf(term);
+    while (pos !== -1) {
+      total++;
+      pos = text.indexOf(term, pos + term.length);
+    }
+    return total;
+  };
+
+  const score = (entry, terms) => {
+    const title = entry.title.toLowerCase();
+    const headings = entry.headings.join(" ").toLowerCase();
+    const body = entry.body.toLowerCase();
+
+    let total = 0;
+    for (const term of terms) {
+      const found =
+        count(title, term) * 10 + count(headings, term) * 5 + count(body, term);
+
+      if (found === 0) {
+        return 0;
+      }
+      total += found;
+    }
+    return total;
+  };
+
+  const snippet = (body, term) => {
+    const pos = body.toLowerCase().indexOf(term);
+    if (pos === -1) {
+      return body.slice(0, 120);
+    }
+    const start = Math.max(0, pos - 40);
+    return (start > 0 ? "…" : "") + body.slice(start, start + 120) + "…";
+  };
+
+  const render = (matches, terms) => {
+    if (matches.length === 0) {
+      results.innerHTML = `<li class="empty">No results</li>`;
+    } else {
+      results.innerHTML = matches
+        .map(
+          ({ entry }) => `
+            <li>
+              <a href="${escape(entry.href)}">
+                <span class="result-title">${escape(entry.title)}</span>
+                <span class="result-snippet">${escape(snippet(entry.body, terms[0]))}</span>
+              </a>
+            </li>`,
+        )
+        .join("");
+    }
+    results.hidden = false;
+  };
+
+  const search = async () => {
+    const terms = input.value.toLowerCase().split(/\s+/).filter(Boolean);
+
+    if (terms.length === 0) {
+      results.hidden = true;
+      return;
+    }
+
+    const entries = await loadIndex();
+    const matches = entries
+      .map((entry) => ({ entry, score: score(entry, terms) }))
+      .filter(({ score }) => score > 0)
+      .sort((a, b) => b.score - a.score)
+      .slice(0, maxResults);
+
+    render(matches, terms);
+  };
+
+  input.addEventListener("focus", loadIndex, { once: true });
+  input.addEventListener("input", search);
+  input.addEventListener("keydown", (e) => {
+    if (e.key === "Escape") {
+      input.value = "";
+      results.hidden = true;
+    }
+  });
+})();
+
+/**
+ * Initializes the version picker.
+ * Switching version opens the same page in the selected version when it exists there,
+ * or the home page of that version otherwise.
+ */
+(function initVersionPicker() {
+  const picker = document.getElementById("version-picker");
+  const webroot = document.body.dataset.webroot || "";
+
+  if (!picker) {
+    return;
+  }
+
+  const currentPage = () => {
+    const path = window.location.pathname
+      .slice(webroot.length)
+      .replace(/\.html$/, "")
+      .replace(/\/index$/, "")
+      .replace(/\/$/, "");
+
+    return path || "/";
+  };
+
+  picker.addEventListener("change", async () => {
+    const res = await fetch(picker.dataset.manifest);
+    const versions = await res.json();
+    const target = versions.find((version) => version.name === picker.value);
+
+    if (target) {
+      window.location.href = target.pages[currentPage()] || target.href;
+    }
+  });
+})();
+
+/**
+ * Initializes the language picker.
+ * The picker is replaced on every navigation, so its changes are listened to on the document.
+ */
+(function initLanguagePicker() {
+  document.addEventListener("change", (e) => {
+    if (e.target.id === "language-picker") {
+      window.location.href = e.target.value;
+    }
+  });
+})();
+
+/**
+ * Switches the theme stylesheet when navigating to a section with a theme of its own.
+ * The theme of the page is carried by the article, which is the only part swapped on navigation.
+ */
+(function initSectionTheme() {
+  document.addEventListener("htmx:afterSettle", () => {
+    const article = document.getElementById("article-content");
+    const stylesheet = document.getElementById("theme-stylesheet");
+
+    if (article && stylesheet && article.dataset.theme && stylesheet.getAttribute("href") !== article.dataset.theme) {
+      stylesheet.setAttribute("href", article.dataset.theme);
+    }
+  });
+})();
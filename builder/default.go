@@ -8,7 +8,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"text/template"
 
 	. "github.com/patrixr/auteur/common"
 	. "github.com/patrixr/auteur/core"
@@ -16,11 +15,14 @@ import (
 
 //go:embed assets/*
 var tmplFS embed.FS
-var templates = template.Must(
-	template.New("").Funcs(templateFuncs).ParseFS(tmplFS, "assets/**/*.tmpl"),
-)
 
-type DefaultBuilder struct{}
+type DefaultBuilder struct {
+	theme      *Theme
+	sources    sourceIndex
+	repository *repository
+	// Whether the site has a feed, pages only link to it when it does
+	feed bool
+}
 
 func NewDefaultBuilder() Builder {
 	return DefaultBuilder{}
@@ -30,12 +32,36 @@ func NewDefaultBuilder() Builder {
 // This is the main entry point for the builder
 func (builder DefaultBuilder) Render(site *Auteur, outfolder string) error {
 	pageKey := site.Slug()
+	cache := site.Cache()
+
+	// The theme is loaded once, and shared with the recursive calls
+	if builder.theme == nil {
+		theme, err := LoadTheme(site.Root().ThemeDir)
+		if err != nil {
+			return err
+		}
+		builder.theme = theme
+	}
 
 	if site.IsRoot() {
+		// Offline builds fail before anything is written if the vendored assets are missing
+		if site.Offline {
+			if err := CheckVendorAssets(); err != nil {
+				return err
+			}
+		}
+
 		site.PrettyPrint()
 		pageKey = "index"
-		if err := Rmdir(outfolder); err != nil {
-			return err
+		builder.sources = newSourceIndex(site)
+		builder.repository = newRepository(site)
+		builder.feed = hasFeed(site)
+
+		// Incremental builds keep the previous output when the page tree is unchanged
+		if cache == nil || !cache.Prepare(site, builder.theme.Signature()+translationsSignature(site)) {
+			if err := Rmdir(outfolder); err != nil {
+				return err
+			}
 		}
 	} else if site.HasChildren() {
 		// We create a folder and use an index.html file
@@ -49,57 +75,35 @@ func (builder DefaultBuilder) Render(site *Auteur, outfolder string) error {
 	}
 
 	if len(site.Content) > 0 || site.IsRoot() {
-		fileName := fmt.Sprintf("%s.html", pageKey)
-		fragFileName := fmt.Sprintf("%s.frag.html", pageKey)
-
-		// Write page file
-		file, err := os.Create(filepath.Join(outfolder, fileName))
-
-		if err != nil {
+		if err := builder.RenderPage(site, outfolder, pageKey); err != nil {
 			return err
 		}
+	}
 
-		html, err := builder.GetHTML(site)
-
-		if err != nil {
+	for _, child := range site.Children() {
+		if err := builder.Render(child, outfolder); err != nil {
 			return err
 		}
+	}
 
-		err = templates.ExecuteTemplate(file, "page.html.tmpl", struct {
-			Fragment   string
-			Site       *Auteur
-			Title      string
-			Webroot    string
-			Distfolder string
-		}{
-			Fragment:   html.String(),
-			Site:       site.Root(),
-			Title:      site.Title,
-			Webroot:    strings.TrimRight(site.Webroot, "/"),
-			Distfolder: outfolder,
-		})
-
-		// Close manually (instead of defer) to avoid stacking up open files
-		file.Close()
+	if site.IsRoot() {
+		if err := builder.CopyAssets(site, outfolder); err != nil {
+			return err
+		}
 
-		if err != nil {
+		if err := builder.WriteSearchIndex(site, outfolder); err != nil {
 			return err
 		}
 
-		// Create frag file
-		if err := os.WriteFile(filepath.Join(outfolder, fragFileName), html.Bytes(), 0644); err != nil {
+		if err := builder.WriteSitemap(site, outfolder); err != nil {
 			return err
 		}
-	}
 
-	for _, child := range site.Children() {
-		if err := builder.Render(child, outfolder); err != nil {
+		if err := builder.WriteRobots(site, outfolder); err != nil {
 			return err
 		}
-	}
 
-	if site.IsRoot() {
-		if err := builder.CopyAssets(outfolder); err != nil {
+		if err := builder.WriteFeed(site, outfolder); err != nil {
 			return err
 		}
 	}
@@ -107,6 +111,69 @@ func (builder DefaultBuilder) Render(site *Auteur, outfolder string) error {
 	return nil
 }
 
+// RenderPage writes the html page and fragment of a single site page
+// Unchanged pages are skipped when building incrementally
+func (builder DefaultBuilder) RenderPage(site *Auteur, outfolder string, pageKey string) error {
+	fileName := fmt.Sprintf("%s.html", pageKey)
+	fragFileName := fmt.Sprintf("%s.frag.html", pageKey)
+
+	if cache := site.Cache(); cache != nil && !cache.Changed(site.Href()) && FileExists(filepath.Join(outfolder, fileName)) {
+		LogDebug("Skipping unchanged page", "href", site.Href())
+		return nil
+	}
+
+	// Write page file
+	file, err := os.Create(filepath.Join(outfolder, fileName))
+
+	if err != nil {
+		return err
+	}
+
+	html, err := builder.GetHTML(site)
+
+	if err != nil {
+		return err
+	}
+
+	err = builder.theme.Templates.ExecuteTemplate(file, "page.html.tmpl", struct {
+		Fragment string
+		Site     *Auteur
+		// Page being rendered, its settings can differ from the site's in sections with their own configuration
+		Page       *Auteur
+		Title      string
+		Webroot    string
+		Distfolder string
+		Toc        []TocEntry
+		// Equivalent of the page in every locale of the site
+		Translations []Translation
+		// Links to the source files of the page
+		Origins []OriginLink
+		// Whether the site has an Atom feed
+		Feed bool
+	}{
+		Fragment:     html.String(),
+		Site:         site.Root(),
+		Page:         site,
+		Title:        site.Title,
+		Webroot:      strings.TrimRight(site.Webroot, "/"),
+		Distfolder:   outfolder,
+		Toc:          extractToc(html.String()),
+		Translations: pageTranslations(site),
+		Origins:      builder.repository.links(site),
+		Feed:         builder.feed,
+	})
+
+	// Close manually (instead of defer) to avoid stacking up open files
+	file.Close()
+
+	if err != nil {
+		return err
+	}
+
+	// Create frag file
+	return os.WriteFile(filepath.Join(outfolder, fragFileName), html.Bytes(), 0644)
+}
+
 func (t DefaultBuilder) GetHTML(site *Auteur) (bytes.Buffer, error) {
 	buffer := bytes.Buffer{}
 
@@ -125,10 +192,24 @@ func (t DefaultBuilder) GetHTML(site *Auteur) (bytes.Buffer, error) {
 		}
 	}
 
-	return buffer, nil
+	sources := t.sources
+	if sources == nil {
+		sources = newSourceIndex(site)
+	}
+
+	resolved := bytes.Buffer{}
+	resolved.WriteString(uniqueHeadingIDs(resolveVendorURLs(site, sources.resolve(site, buffer.String()))))
+
+	return resolved, nil
 }
 
-func (t DefaultBuilder) CopyAssets(outfolder string) error {
+func (t DefaultBuilder) CopyAssets(site *Auteur, outfolder string) error {
+	if site.Offline {
+		if err := t.CopyVendorAssets(outfolder); err != nil {
+			return err
+		}
+	}
+
 	filesToCopy := []string{"assets/default/script.js", "assets/default/style.css"}
 
 	for _, file := range filesToCopy {
@@ -149,5 +230,15 @@ func (t DefaultBuilder) CopyAssets(outfolder string) error {
 			return err
 		}
 	}
+
+	if err := t.WriteHighlightCSS(site, outfolder); err != nil {
+		return err
+	}
+
+	// Theme files are copied last to override the default ones
+	if t.theme != nil {
+		return t.theme.CopyStatic(outfolder)
+	}
+
 	return nil
 }
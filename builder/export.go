@@ -0,0 +1,204 @@
+package builder
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+	"gopkg.in/yaml.v3"
+)
+
+// Version of the export format, bumped on every breaking change to it
+const ExportSchemaVersion = 1
+
+// ExportSchema is the JSON Schema of the current version of the export format,
+// the YAML export follows it as well
+//
+//go:embed export.schema.json
+var ExportSchema []byte
+
+type ExportFormat string
+
+const (
+	ExportJSON ExportFormat = "json"
+	ExportYAML ExportFormat = "yaml"
+)
+
+// ExportedSite is the root of an exported site
+type ExportedSite struct {
+	SchemaVersion int          `json:"schemaVersion" yaml:"schemaVersion"`
+	Title         string       `json:"title" yaml:"title"`
+	Desc          string       `json:"desc" yaml:"desc"`
+	Version       string       `json:"version" yaml:"version"`
+	Lang          string       `json:"lang,omitempty" yaml:"lang,omitempty"`
+	Webroot       string       `json:"webroot" yaml:"webroot"`
+	BaseURL       string       `json:"baseURL,omitempty" yaml:"baseURL,omitempty"`
+	Root          ExportedPage `json:"root" yaml:"root"`
+}
+
+// ExportedPage is a page of an exported site, along with its sub-pages
+type ExportedPage struct {
+	Title string `json:"title" yaml:"title"`
+	// Path of the page in the tree, e.g /guides/setup
+	Href string `json:"href" yaml:"href"`
+	// Url the page is served at, including the webroot
+	URL      string `json:"url" yaml:"url"`
+	Priority int    `json:"priority" yaml:"priority"`
+	// Source files that contributed to the page, relative to the root folder
+	Sources  []string          `json:"sources" yaml:"sources"`
+	Content  []ExportedContent `json:"content" yaml:"content"`
+	HTML     string            `json:"html" yaml:"html"`
+	Children []ExportedPage    `json:"children" yaml:"children"`
+}
+
+// ExportedContent describes a chunk of content of a page
+type ExportedContent struct {
+	Title    string   `json:"title,omitempty" yaml:"title,omitempty"`
+	Priority int      `json:"priority" yaml:"priority"`
+	Locale   string   `json:"locale,omitempty" yaml:"locale,omitempty"`
+	Meta     Metadata `json:"meta" yaml:"meta"`
+	// Location of the content in its source file, relative to the root folder
+	Origin *Origin `json:"origin,omitempty" yaml:"origin,omitempty"`
+}
+
+// ExportBuilder writes the whole page tree to a single structured file,
+// for other tools to consume the site programmatically
+type ExportBuilder struct {
+	Format ExportFormat
+}
+
+func NewJSONBuilder() Builder {
+	return ExportBuilder{Format: ExportJSON}
+}
+
+func NewYAMLBuilder() Builder {
+	return ExportBuilder{Format: ExportYAML}
+}
+
+// Render writes the exported site as site.json or site.yaml inside the output folder
+func (builder ExportBuilder) Render(site *Auteur, outfolder string) error {
+	exported, err := builder.Export(site)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+
+	switch builder.Format {
+	case ExportJSON:
+		var buffer bytes.Buffer
+		encoder := json.NewEncoder(&buffer)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(exported)
+		data = buffer.Bytes()
+	case ExportYAML:
+		data, err = yaml.Marshal(exported)
+	default:
+		return fmt.Errorf("Unknown export format: %s", builder.Format)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := Mkdirp(outfolder); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(outfolder, "site."+string(builder.Format)), data, 0644)
+}
+
+// Export returns the serializable representation of the site
+func (builder ExportBuilder) Export(site *Auteur) (ExportedSite, error) {
+	renderer := DefaultBuilder{sources: newSourceIndex(site)}
+	webroot := strings.TrimRight(site.Webroot, "/")
+
+	var export func(page *Auteur) (ExportedPage, error)
+	export = func(page *Auteur) (ExportedPage, error) {
+		url, _ := pageURLs(webroot, page)
+
+		exported := ExportedPage{
+			Title:    page.Title,
+			Href:     page.Href(),
+			URL:      url,
+			Priority: page.Priority,
+			Sources:  []string{},
+			Content:  []ExportedContent{},
+			Children: []ExportedPage{},
+		}
+
+		for _, source := range page.Sources() {
+			rel, err := site.GetRelativePath(source)
+			if err != nil {
+				return exported, err
+			}
+			exported.Sources = append(exported.Sources, filepath.ToSlash(rel))
+		}
+
+		for _, content := range page.Content {
+			meta := content.Meta()
+			if meta == nil {
+				meta = Metadata{}
+			}
+
+			exportedContent := ExportedContent{
+				Title:    content.Title(),
+				Priority: content.Priority(),
+				Locale:   content.Locale(),
+				Meta:     meta,
+			}
+
+			if origin := content.Origin(); origin.File != "" {
+				rel, err := site.GetRelativePath(origin.File)
+				if err != nil {
+					return exported, err
+				}
+				origin.File = filepath.ToSlash(rel)
+				exportedContent.Origin = &origin
+			}
+
+			exported.Content = append(exported.Content, exportedContent)
+		}
+
+		if len(page.Content) > 0 {
+			html, err := renderer.GetHTML(page)
+			if err != nil {
+				return exported, err
+			}
+			exported.HTML = html.String()
+		}
+
+		for _, child := range page.Children() {
+			exportedChild, err := export(child)
+			if err != nil {
+				return exported, err
+			}
+			exported.Children = append(exported.Children, exportedChild)
+		}
+
+		return exported, nil
+	}
+
+	root, err := export(site)
+	if err != nil {
+		return ExportedSite{}, err
+	}
+
+	return ExportedSite{
+		SchemaVersion: ExportSchemaVersion,
+		Title:         site.Title,
+		Desc:          site.Desc,
+		Version:       site.Version,
+		Lang:          site.Lang,
+		Webroot:       site.Webroot,
+		BaseURL:       site.BaseURL,
+		Root:          root,
+	}, nil
+}
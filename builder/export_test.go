@@ -0,0 +1,107 @@
+package builder
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExport(t *testing.T) {
+	outfolder := t.TempDir()
+
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+	site.Webroot = "/docs"
+
+	site.AddContent(linkContent{path: []string{}, html: `<p>Home</p>`})
+	site.AddContent(linkContent{path: []string{"guides", "setup"}, html: `<h1 id="setup">Setup</h1>`, meta: Metadata{"tags": "install"}})
+
+	t.Run("JSON export contains the whole page tree", func(t *testing.T) {
+		assert.NoError(t, NewJSONBuilder().Render(site, outfolder))
+
+		data, err := os.ReadFile(filepath.Join(outfolder, "site.json"))
+		assert.NoError(t, err)
+
+		var exported ExportedSite
+		assert.NoError(t, json.Unmarshal(data, &exported))
+
+		assert.Equal(t, ExportSchemaVersion, exported.SchemaVersion)
+		assert.Equal(t, "/docs/index", exported.Root.URL)
+		assert.Equal(t, "<p>Home</p>", exported.Root.HTML)
+
+		guides := exported.Root.Children[0]
+		assert.Equal(t, "/guides", guides.Href)
+		assert.Equal(t, "", guides.HTML)
+
+		setup := guides.Children[0]
+		assert.Equal(t, "/docs/guides/setup", setup.URL)
+		assert.Equal(t, "install", setup.Content[0].Meta["tags"])
+		assert.Contains(t, setup.HTML, `id="setup"`)
+	})
+
+	t.Run("YAML export uses the same schema", func(t *testing.T) {
+		assert.NoError(t, NewYAMLBuilder().Render(site, outfolder))
+
+		data, err := os.ReadFile(filepath.Join(outfolder, "site.yaml"))
+		assert.NoError(t, err)
+
+		var exported ExportedSite
+		assert.NoError(t, yaml.Unmarshal(data, &exported))
+		assert.Equal(t, "setup", exported.Root.Children[0].Children[0].Title)
+	})
+
+	t.Run("Exports follow the schema", func(t *testing.T) {
+		site.AddContent(originContent{
+			linkContent: linkContent{path: []string{"usage"}, html: `<p>Usage</p>`},
+			origin:      Origin{File: filepath.Join(site.Rootdir, "usage.md"), StartLine: 1, EndLine: 4},
+		})
+
+		document, err := jsonschema.UnmarshalJSON(bytes.NewReader(ExportSchema))
+		assert.NoError(t, err)
+
+		compiler := jsonschema.NewCompiler()
+		assert.NoError(t, compiler.AddResource("export.schema.json", document))
+
+		schema, err := compiler.Compile("export.schema.json")
+		assert.NoError(t, err)
+
+		for _, builder := range []Builder{NewJSONBuilder(), NewYAMLBuilder()} {
+			format := builder.(ExportBuilder).Format
+			assert.NoError(t, builder.Render(site, outfolder))
+
+			data, err := os.ReadFile(filepath.Join(outfolder, "site."+string(format)))
+			assert.NoError(t, err)
+
+			var exported any
+			if format == ExportJSON {
+				exported, err = jsonschema.UnmarshalJSON(bytes.NewReader(data))
+			} else {
+				err = yaml.Unmarshal(data, &exported)
+			}
+
+			assert.NoError(t, err)
+			assert.NoError(t, schema.Validate(exported), format)
+		}
+	})
+
+	t.Run("The schema describes the current version", func(t *testing.T) {
+		var schema struct {
+			Properties struct {
+				SchemaVersion struct {
+					Const int `json:"const"`
+				} `json:"schemaVersion"`
+			} `json:"properties"`
+		}
+
+		assert.NoError(t, json.Unmarshal(ExportSchema, &schema))
+		assert.Equal(t, ExportSchemaVersion, schema.Properties.SchemaVersion.Const)
+	})
+}
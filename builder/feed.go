@@ -0,0 +1,171 @@
+package builder
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	. "github.com/patrixr/auteur/core"
+)
+
+const FeedFile = "feed.xml"
+
+// Length of the summary of each feed entry, in characters
+const feedSummaryLength = 280
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title   string   `xml:"title"`
+	ID      string   `xml:"id"`
+	Link    atomLink `xml:"link"`
+	Updated string   `xml:"updated"`
+	Summary string   `xml:"summary,omitempty"`
+
+	date time.Time
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	ID       string      `xml:"id"`
+	Author   atomAuthor  `xml:"author"`
+	Links    []atomLink  `xml:"link"`
+	Updated  string      `xml:"updated"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+// BuildFeed returns an Atom feed of the pages carrying a date in their frontmatter,
+// most recent first. The feed is updated with its most recent entry
+func (builder DefaultBuilder) BuildFeed(site *Auteur) ([]byte, error) {
+	home := absoluteURL(site, site)
+
+	author := site.Author
+	if author == "" {
+		author = site.Title
+	}
+
+	feed := atomFeed{
+		Xmlns:    "http://www.w3.org/2005/Atom",
+		Title:    site.Title,
+		Subtitle: site.Desc,
+		ID:       home,
+		Author:   atomAuthor{Name: author},
+		Links: []atomLink{
+			{Href: home},
+			{Href: home + FeedFile, Rel: "self"},
+		},
+		Entries: []atomEntry{},
+	}
+
+	var traverse func(page *Auteur) error
+	traverse = func(page *Auteur) error {
+		if date, ok := pageDate(page); ok {
+			buffer, err := builder.GetHTML(page)
+			if err != nil {
+				return err
+			}
+
+			url := absoluteURL(site, page)
+
+			feed.Entries = append(feed.Entries, atomEntry{
+				Title:   page.Title,
+				ID:      url,
+				Link:    atomLink{Href: url},
+				Updated: date.Format(time.RFC3339),
+				Summary: summarize(htmlToText(buffer.String()), feedSummaryLength),
+				date:    date,
+			})
+		}
+
+		for _, child := range page.Children() {
+			if err := traverse(child); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := traverse(site); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(feed.Entries, func(i, j int) bool {
+		return feed.Entries[i].date.After(feed.Entries[j].date)
+	})
+
+	// Feeds without entries aren't written, the zero date keeps them valid and stable
+	feed.Updated = time.Time{}.Format(time.RFC3339)
+	if len(feed.Entries) > 0 {
+		feed.Updated = feed.Entries[0].Updated
+	}
+
+	data, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+// WriteFeed writes the Atom feed at the root of the output folder
+// Feeds require absolute urls, so nothing is written without a baseURL or without dated pages
+func (builder DefaultBuilder) WriteFeed(site *Auteur, outfolder string) error {
+	if !hasFeed(site) {
+		return nil
+	}
+
+	data, err := builder.BuildFeed(site)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(outfolder, FeedFile), data, 0644)
+}
+
+// hasFeed returns true if the site has a baseURL and at least one dated page
+func hasFeed(site *Auteur) bool {
+	if site.BaseURL == "" {
+		return false
+	}
+
+	var dated func(page *Auteur) bool
+	dated = func(page *Auteur) bool {
+		if _, ok := pageDate(page); ok {
+			return true
+		}
+
+		return slices.ContainsFunc(page.Children(), dated)
+	}
+
+	return dated(site)
+}
+
+// summarize truncates the text to the given number of characters, on a word boundary
+func summarize(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+
+	summary := string(runes[:length])
+	if i := strings.LastIndex(summary, " "); i > 0 {
+		summary = summary[:i]
+	}
+
+	return summary + "…"
+}
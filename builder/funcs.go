@@ -2,11 +2,7 @@ package builder
 
 import (
 	"html/template"
-	"io"
-	"net/http"
 	"net/url"
-	"os"
-	"path/filepath"
 	"regexp"
 
 	"github.com/patrixr/q"
@@ -18,20 +14,7 @@ var templateFuncs = template.FuncMap{
 		q.AssertNoError(err)
 		return res
 	},
-	"Vendor": func(webroot string, distfolder string, url string) string {
-		resp, err := http.Get(url)
-		q.AssertNoError(err)
-		defer resp.Body.Close()
-
-		out, err := os.Create(filepath.Join(distfolder, filepath.Base(url)))
-		q.AssertNoError(err)
-		defer out.Close()
-
-		_, err = io.Copy(out, resp.Body)
-		q.AssertNoError(err)
-
-		return filepath.Join(webroot, filepath.Base(url))
-	},
+	"Vendor": VendorURL,
 	"CleanTitle": func(txt string) string {
 		return regexp.MustCompile("[-_]+").ReplaceAllString(txt, " ")
 	},
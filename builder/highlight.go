@@ -0,0 +1,78 @@
+package builder
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/styles"
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+)
+
+// Class toggled on the document element by the theme switch
+const darkModeClass = "wa-dark"
+
+// HighlightCSS generates the stylesheet of the highlighted code blocks.
+// The light style applies by default, the dark one when dark mode is on
+func HighlightCSS(config HighlightConfig) (string, error) {
+	formatter := chromahtml.New(
+		chromahtml.WithClasses(true),
+		chromahtml.WithCSSComments(false),
+	)
+
+	var light, dark bytes.Buffer
+
+	if err := formatter.WriteCSS(&light, highlightStyle(config.Light)); err != nil {
+		return "", err
+	}
+
+	if err := formatter.WriteCSS(&dark, highlightStyle(config.Dark)); err != nil {
+		return "", err
+	}
+
+	return light.String() + scopeCSS(dark.String(), "."+darkModeClass), nil
+}
+
+// WriteHighlightCSS writes the highlight.css stylesheet in the output folder
+func (builder DefaultBuilder) WriteHighlightCSS(site *Auteur, outfolder string) error {
+	css, err := HighlightCSS(site.Highlight)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(outfolder, "highlight.css"), []byte(css), 0644)
+}
+
+func highlightStyle(name string) *chroma.Style {
+	style, ok := styles.Registry[strings.ToLower(name)]
+	if !ok {
+		LogWarn("Unknown highlight style, using the fallback", "style", name)
+		return styles.Fallback
+	}
+	return style
+}
+
+// scopeCSS prefixes the selectors of each rule (one per line) with the scope selector
+func scopeCSS(css string, scope string) string {
+	var sb strings.Builder
+
+	for _, line := range strings.Split(css, "\n") {
+		selectors, rule, found := strings.Cut(line, "{")
+		if !found {
+			continue
+		}
+
+		scoped := []string{}
+		for _, selector := range strings.Split(selectors, ",") {
+			scoped = append(scoped, scope+" "+strings.TrimSpace(selector))
+		}
+
+		sb.WriteString(strings.Join(scoped, ", ") + " {" + rule + "\n")
+	}
+
+	return sb.String()
+}
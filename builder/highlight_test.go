@@ -0,0 +1,22 @@
+package builder
+
+import (
+	"testing"
+
+	. "github.com/patrixr/auteur/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlightCSS(t *testing.T) {
+	t.Run("Dark style is scoped to dark mode", func(t *testing.T) {
+		css, err := HighlightCSS(HighlightConfig{Light: "github", Dark: "github-dark"})
+		assert.NoError(t, err)
+		assert.Contains(t, css, ".chroma { background-color: #f7f7f7;")
+		assert.Contains(t, css, ".wa-dark .chroma { color: #e6edf3; background-color: #0d1117;")
+	})
+
+	t.Run("Every selector of a rule is scoped", func(t *testing.T) {
+		scoped := scopeCSS(".a, .b .c { color: red }\n", ".wa-dark")
+		assert.Equal(t, ".wa-dark .a, .wa-dark .b .c { color: red }\n", scoped)
+	})
+}
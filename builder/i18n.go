@@ -0,0 +1,64 @@
+package builder
+
+import (
+	"strings"
+
+	. "github.com/patrixr/auteur/core"
+)
+
+// Translation is the equivalent of a page in one of the locales of the site
+type Translation struct {
+	Lang    string
+	Href    string
+	Current bool
+}
+
+// pageTranslations returns the url of the page in every locale of the site,
+// absolute when the site has a baseURL. Locales missing the page link to their home page instead
+func pageTranslations(page *Auteur) []Translation {
+	translations := []Translation{}
+	baseURL := strings.TrimRight(page.Root().BaseURL, "/")
+
+	for _, site := range page.Translations() {
+		webroot := strings.TrimRight(site.Webroot, "/")
+		target := site.FindPage(page.Href())
+
+		if target == nil || (len(target.Content) == 0 && !target.IsRoot()) {
+			target = site
+		}
+
+		href, _ := pageURLs(webroot, target)
+
+		translations = append(translations, Translation{
+			Lang:    site.Lang,
+			Href:    baseURL + href,
+			Current: site.Lang == page.Lang,
+		})
+	}
+
+	return translations
+}
+
+// translationsSignature describes the pages of the other locales of the site,
+// pages link to their translations so any change to them requires a new render
+func translationsSignature(site *Auteur) string {
+	var sb strings.Builder
+
+	var traverse func(page *Auteur)
+	traverse = func(page *Auteur) {
+		if len(page.Content) > 0 {
+			sb.WriteString(page.Lang + page.Href() + "\n")
+		}
+		for _, child := range page.Children() {
+			traverse(child)
+		}
+	}
+
+	for _, translation := range site.Translations() {
+		if translation != site {
+			traverse(translation)
+		}
+	}
+
+	return sb.String()
+}
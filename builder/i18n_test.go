@@ -0,0 +1,64 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/patrixr/auteur/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslations(t *testing.T) {
+	config, err := DetectConfig()
+	assert.NoError(t, err)
+	config.Webroot = "/docs"
+	config.BaseURL = "https://example.com"
+	config.Locales = []string{"en", "fr"}
+
+	en := NewAuteurFromConfig(config.ForLocale("en"))
+	en.AddContent(linkContent{path: []string{}, html: `<p>Home</p>`})
+	en.AddContent(linkContent{path: []string{"guides", "setup"}, html: `<p>Setup</p>`})
+	en.AddContent(linkContent{path: []string{"guides", "deploy"}, html: `<p>Deploy</p>`})
+
+	fr := NewAuteurFromConfig(config.ForLocale("fr"))
+	fr.AddContent(linkContent{path: []string{}, html: `<p>Accueil</p>`})
+	fr.AddContent(linkContent{path: []string{"guides", "setup"}, html: `<p>Installation</p>`})
+
+	sites := []*Auteur{en, fr}
+	for _, site := range sites {
+		site.UseTranslations(sites)
+	}
+
+	t.Run("Pages link to their translations", func(t *testing.T) {
+		assert.Equal(t, []Translation{
+			{Lang: "en", Href: "https://example.com/docs/en/guides/setup", Current: true},
+			{Lang: "fr", Href: "https://example.com/docs/fr/guides/setup"},
+		}, pageTranslations(en.FindPage("/guides/setup")))
+	})
+
+	t.Run("Untranslated pages link to the home page of the locale", func(t *testing.T) {
+		assert.Equal(t, []Translation{
+			{Lang: "en", Href: "https://example.com/docs/en/guides/deploy", Current: true},
+			{Lang: "fr", Href: "https://example.com/docs/fr/index"},
+		}, pageTranslations(en.FindPage("/guides/deploy")))
+	})
+
+	t.Run("Rendered pages declare their alternates", func(t *testing.T) {
+		outfolder := t.TempDir()
+		assert.NoError(t, NewDefaultBuilder().Render(fr, outfolder))
+
+		data, err := os.ReadFile(filepath.Join(outfolder, "guides", "setup.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `<link rel="alternate" hreflang="en" href="https://example.com/docs/en/guides/setup" />`)
+		assert.Contains(t, string(data), `<link rel="alternate" hreflang="fr" href="https://example.com/docs/fr/guides/setup" />`)
+	})
+
+	t.Run("Sites without locales have no translations", func(t *testing.T) {
+		site, err := NewAuteur()
+		assert.NoError(t, err)
+		site.AddContent(linkContent{path: []string{"intro"}, html: `<p>Intro</p>`})
+
+		assert.Empty(t, pageTranslations(site.FindPage("/intro")))
+	})
+}
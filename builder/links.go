@@ -0,0 +1,236 @@
+package builder
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	. "github.com/patrixr/auteur/core"
+)
+
+var (
+	linkRexp = regexp.MustCompile(`(?is)<a\s[^>]*?href\s*=\s*"([^"]*)"`)
+	idRexp   = regexp.MustCompile(`(?is)\sid\s*=\s*"([^"]*)"`)
+)
+
+// BrokenLink is an internal link of a rendered page that doesn't resolve
+// to a generated page, file or anchor
+type BrokenLink struct {
+	Page   string
+	Link   string
+	Reason string
+	// Source file and line the link was found in, when it could be located
+	File string
+	Line int
+}
+
+func (link BrokenLink) String() string {
+	location := link.Page
+	if link.File != "" {
+		location = fmt.Sprintf("%s:%d", link.File, link.Line)
+	}
+	return fmt.Sprintf("%s: broken link %q (%s)", location, link.Link, link.Reason)
+}
+
+// CheckLinks validates the internal links and anchors of every rendered page of the site.
+// It must run after the site has been rendered into the output folder
+func CheckLinks(site *Auteur, outfolder string) ([]BrokenLink, error) {
+	broken := []BrokenLink{}
+	webroot := "/" + strings.Trim(site.Webroot, "/")
+	ids := map[string]map[string]bool{}
+
+	// Returns the ids declared by a rendered file, loaded lazily
+	anchorsOf := func(file string) (map[string]bool, error) {
+		if found, ok := ids[file]; ok {
+			return found, nil
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		found := map[string]bool{}
+		for _, match := range idRexp.FindAllStringSubmatch(string(data), -1) {
+			found[html.UnescapeString(match[1])] = true
+		}
+
+		ids[file] = found
+		return found, nil
+	}
+
+	var traverse func(page *Auteur) error
+	traverse = func(page *Auteur) error {
+		if len(page.Content) > 0 || page.IsRoot() {
+			frag := PageFile(page, outfolder) + ".frag.html"
+
+			data, err := os.ReadFile(frag)
+			if err != nil {
+				return err
+			}
+
+			for _, match := range linkRexp.FindAllStringSubmatch(string(data), -1) {
+				link := html.UnescapeString(match[1])
+				reason := checkLink(page, link, webroot, outfolder, frag, anchorsOf)
+
+				if reason == "" {
+					continue
+				}
+
+				file, line := locateLink(page.Sources(), link)
+
+				// Links to source files were rewritten, fallback to looking for their anchor
+				if file == "" && strings.Contains(link, "#") {
+					file, line = locateLink(page.Sources(), link[strings.Index(link, "#"):])
+				}
+
+				broken = append(broken, BrokenLink{
+					Page:   page.Href(),
+					Link:   link,
+					Reason: reason,
+					File:   file,
+					Line:   line,
+				})
+			}
+		}
+
+		for _, child := range page.Children() {
+			if err := traverse(child); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := traverse(site); err != nil {
+		return nil, err
+	}
+
+	return broken, nil
+}
+
+// PageFile returns the path of the rendered page in the output folder, without extension
+func PageFile(page *Auteur, outfolder string) string {
+	if page.IsRoot() {
+		return filepath.Join(outfolder, "index")
+	}
+
+	file := filepath.Join(outfolder, filepath.FromSlash(page.Href()))
+
+	if page.HasChildren() {
+		return filepath.Join(file, "index")
+	}
+
+	return file
+}
+
+// checkLink returns the reason a link is broken, or an empty string if it is valid
+func checkLink(page *Auteur, link string, webroot string, outfolder string, frag string, anchorsOf func(string) (map[string]bool, error)) string {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return "invalid url"
+	}
+
+	// External links and other protocols are not checked
+	if parsed.Scheme != "" || parsed.Host != "" || strings.HasPrefix(link, "//") {
+		return ""
+	}
+
+	target := frag
+
+	if parsed.Path != "" {
+		resolved := parsed.Path
+
+		if !strings.HasPrefix(resolved, "/") {
+			resolved = path.Join(pageDir(page), resolved)
+		} else if webroot != "/" {
+			trimmed, ok := strings.CutPrefix(resolved, webroot)
+			if !ok {
+				return "outside of the webroot"
+			}
+			resolved = "/" + strings.TrimLeft(trimmed, "/")
+		}
+
+		file, ok := resolveOutputFile(outfolder, resolved)
+		if !ok {
+			return "page not found"
+		}
+		target = file
+	}
+
+	if parsed.Fragment == "" || !strings.HasSuffix(target, ".html") {
+		return ""
+	}
+
+	anchors, err := anchorsOf(target)
+	if err != nil {
+		return err.Error()
+	}
+
+	if !anchors[parsed.Fragment] {
+		return fmt.Sprintf("anchor #%s not found", parsed.Fragment)
+	}
+
+	return ""
+}
+
+// pageDir returns the url folder relative links of a page are resolved against
+func pageDir(page *Auteur) string {
+	if page.IsRoot() || page.HasChildren() {
+		return page.Href()
+	}
+	return path.Dir(page.Href())
+}
+
+// resolveOutputFile finds the file of the output folder a url path points at,
+// following the same conventions as the rendered pages
+func resolveOutputFile(outfolder string, urlPath string) (string, bool) {
+	base := filepath.Join(outfolder, filepath.FromSlash(path.Clean(urlPath)))
+
+	candidates := []string{
+		base,
+		base + ".html",
+		filepath.Join(base, "index.html"),
+	}
+
+	for _, candidate := range candidates {
+		info, err := os.Stat(candidate)
+		if err == nil && !info.IsDir() {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
+
+// locateLink finds the first source file and line containing the link
+func locateLink(sources []string, link string) (string, int) {
+	for _, source := range sources {
+		file, err := os.Open(source)
+		if err != nil {
+			continue
+		}
+
+		scanner := bufio.NewScanner(file)
+		line := 0
+
+		for scanner.Scan() {
+			line++
+			if strings.Contains(scanner.Text(), link) {
+				file.Close()
+				return source, line
+			}
+		}
+
+		file.Close()
+	}
+
+	return "", 0
+}
@@ -0,0 +1,100 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+	"github.com/stretchr/testify/assert"
+)
+
+type linkContent struct {
+	path []string
+	html string
+	meta Metadata
+}
+
+func (c linkContent) Path() []string    { return c.path }
+func (c linkContent) Len() int          { return len(c.html) }
+func (c linkContent) Data() string      { return c.html }
+func (c linkContent) Meta() Metadata    { return c.meta }
+func (c linkContent) Title() string     { return "" }
+func (c linkContent) Type() ContentType { return HTML }
+func (c linkContent) Priority() int     { return 0 }
+func (c linkContent) Locale() string    { return "" }
+func (c linkContent) Origin() Origin    { return Origin{} }
+
+func TestCheckLinks(t *testing.T) {
+	outfolder := t.TempDir()
+
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+
+	site.AddContent(linkContent{path: []string{}, html: `<a href="guides/setup">setup</a>`})
+	site.AddContent(linkContent{path: []string{"guides", "setup"}, html: `<h2 id="install">Install</h2><a href="../missing">missing</a>`})
+	site.AddContent(linkContent{path: []string{"about"}, html: `
+		<a href="/guides/setup#install">valid anchor</a>
+		<a href="/guides/setup#nope">invalid anchor</a>
+		<a href="#top">invalid local anchor</a>
+		<a href="https://example.com/nope">external</a>
+		<a href="/style.css">asset</a>
+	`})
+
+	assert.NoError(t, NewDefaultBuilder().Render(site, outfolder))
+	assert.FileExists(t, filepath.Join(outfolder, "guides", "setup.frag.html"))
+
+	broken, err := CheckLinks(site, outfolder)
+	assert.NoError(t, err)
+
+	links := map[string]string{}
+	for _, link := range broken {
+		links[link.Link] = link.Page
+	}
+
+	assert.Len(t, broken, 3)
+	assert.Equal(t, "/guides/setup", links["../missing"])
+	assert.Equal(t, "/about", links["/guides/setup#nope"])
+	assert.Equal(t, "/about", links["#top"])
+}
+
+func TestLocateLink(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "page.md")
+	os.WriteFile(source, []byte("# Title\n\nSee [setup](../setup.md)\n"), 0644)
+
+	file, line := locateLink([]string{source}, "../setup.md")
+	assert.Equal(t, source, file)
+	assert.Equal(t, 3, line)
+}
+
+func TestSourceLinks(t *testing.T) {
+	rootdir := t.TempDir()
+	os.MkdirAll(filepath.Join(rootdir, "guides"), 0755)
+
+	setup := filepath.Join(rootdir, "guides", "setup.md")
+	index := filepath.Join(rootdir, "index.md")
+	os.WriteFile(setup, []byte("# Setup"), 0644)
+	os.WriteFile(index, []byte("# Index"), 0644)
+
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+	site.Webroot = "/docs"
+
+	_, html, err := MarkdownFileToHTMLWithMeta([]byte(`[setup](guides/setup.md#install) [missing](missing.md) [web](https://example.com)`), index)
+	assert.NoError(t, err)
+
+	page := site.AddContent(linkContent{path: []string{"guides", "setup"}, html: "<h1>Setup</h1>"})
+	root := site.AddContent(linkContent{path: []string{}, html: html})
+
+	// Sources are normally recorded during ingestion
+	assert.NotNil(t, page)
+	assert.NotNil(t, root)
+
+	sources := sourceIndex{setup: page, index: root}
+	resolved := sources.resolve(site, html)
+
+	assert.Contains(t, resolved, `href="/docs/guides/setup#install"`)
+	assert.Contains(t, resolved, `href="missing.md"`)
+	assert.Contains(t, resolved, `href="https://example.com"`)
+}
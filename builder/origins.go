@@ -0,0 +1,149 @@
+package builder
+
+import (
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+)
+
+// OriginLink links a chunk of content of a page to its source file
+type OriginLink struct {
+	// Path of the file in the repository
+	File string
+	// Lines the content spans, e.g 12-20
+	Lines     string
+	Edit      string
+	View      string
+	EditLabel string
+}
+
+// repository builds the links to the source files of a site
+type repository struct {
+	dir       string
+	url       string
+	branch    string
+	edit      string
+	view      string
+	editLabel string
+}
+
+// newRepository returns the repository the site is hosted in, or nil
+// if no repository url is configured
+func newRepository(site *Auteur) *repository {
+	config := site.Repository
+
+	if config.URL == "" {
+		return nil
+	}
+
+	repo := &repository{
+		dir:       config.Dir,
+		url:       strings.TrimRight(config.URL, "/"),
+		branch:    config.Branch,
+		edit:      config.EditURL,
+		view:      config.SourceURL,
+		editLabel: "Edit this page",
+	}
+
+	if repo.dir == "" {
+		toplevel, err := GitToplevel(site.Rootdir)
+		if err != nil {
+			toplevel = site.Rootdir
+		}
+		repo.dir = toplevel
+	}
+
+	host := ""
+	if parsed, err := url.Parse(repo.url); err == nil {
+		host = parsed.Host
+	}
+
+	switch {
+	case strings.Contains(host, "github"):
+		repo.editLabel = "Edit on GitHub"
+		repo.edit = defaultPattern(repo.edit, "{url}/edit/{branch}/{path}")
+		repo.view = defaultPattern(repo.view, "{url}/blob/{branch}/{path}#L{start}-L{end}")
+	case strings.Contains(host, "gitlab"):
+		repo.editLabel = "Edit on GitLab"
+		repo.edit = defaultPattern(repo.edit, "{url}/-/edit/{branch}/{path}")
+		repo.view = defaultPattern(repo.view, "{url}/-/blob/{branch}/{path}#L{start}-{end}")
+	}
+
+	if repo.edit == "" && repo.view == "" {
+		LogWarn("Unknown repository host, set repository.editURL and repository.sourceURL to link to the sources", "url", repo.url)
+		return nil
+	}
+
+	return repo
+}
+
+// links returns a link for every distinct origin of the content of a page
+func (repo *repository) links(page *Auteur) []OriginLink {
+	if repo == nil {
+		return nil
+	}
+
+	links := []OriginLink{}
+
+	for _, content := range page.Content {
+		origin := content.Origin()
+		if origin.File == "" {
+			continue
+		}
+
+		rel, err := filepath.Rel(repo.dir, origin.File)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+
+		link := OriginLink{
+			File:      filepath.ToSlash(rel),
+			EditLabel: repo.editLabel,
+		}
+
+		if origin.StartLine > 0 {
+			link.Lines = strconv.Itoa(origin.StartLine) + "-" + strconv.Itoa(origin.EndLine)
+		}
+
+		link.Edit = repo.expand(repo.edit, link.File, origin)
+		link.View = repo.expand(repo.view, link.File, origin)
+
+		if !slices.Contains(links, link) {
+			links = append(links, link)
+		}
+	}
+
+	return links
+}
+
+// expand replaces the placeholders of a url pattern. Line placeholders can only
+// be used in the fragment of the url, which is dropped for content without lines
+func (repo *repository) expand(pattern string, path string, origin Origin) string {
+	if pattern == "" {
+		return ""
+	}
+
+	if origin.StartLine == 0 {
+		pattern, _, _ = strings.Cut(pattern, "#")
+	}
+
+	return strings.NewReplacer(
+		"{url}", repo.url,
+		"{branch}", repo.branch,
+		"{path}", path,
+		"{start}", strconv.Itoa(origin.StartLine),
+		"{end}", strconv.Itoa(origin.EndLine),
+	).Replace(pattern)
+}
+
+func defaultPattern(pattern string, fallback string) string {
+	if pattern != "" {
+		return pattern
+	}
+	return fallback
+}
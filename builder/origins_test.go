@@ -0,0 +1,75 @@
+package builder
+
+import (
+	"testing"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+	"github.com/stretchr/testify/assert"
+)
+
+type originContent struct {
+	linkContent
+	origin Origin
+}
+
+func (c originContent) Origin() Origin { return c.origin }
+
+func TestOriginLinks(t *testing.T) {
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+	site.Repository = RepositoryConfig{URL: "https://github.com/acme/docs/", Branch: "main", Dir: "/repo"}
+
+	page := site.AddContent(originContent{
+		linkContent: linkContent{path: []string{"guide"}, html: "<p>Guide</p>", meta: Metadata{}},
+		origin:      Origin{File: "/repo/src/main.go", StartLine: 3, EndLine: 8},
+	})
+	site.AddContent(originContent{
+		linkContent: linkContent{path: []string{"guide"}, html: "<p>Setup</p>"},
+		origin:      Origin{File: "/repo/docs/setup.md"},
+	})
+	site.AddContent(originContent{
+		linkContent: linkContent{path: []string{"guide"}, html: "<p>Outside</p>"},
+		origin:      Origin{File: "/elsewhere/notes.md"},
+	})
+
+	t.Run("GitHub links are detected from the repository url", func(t *testing.T) {
+		links := newRepository(site).links(page)
+		assert.Len(t, links, 2)
+
+		assert.Contains(t, links, OriginLink{
+			File:      "src/main.go",
+			Lines:     "3-8",
+			Edit:      "https://github.com/acme/docs/edit/main/src/main.go",
+			View:      "https://github.com/acme/docs/blob/main/src/main.go#L3-L8",
+			EditLabel: "Edit on GitHub",
+		})
+
+		assert.Contains(t, links, OriginLink{
+			File:      "docs/setup.md",
+			Edit:      "https://github.com/acme/docs/edit/main/docs/setup.md",
+			View:      "https://github.com/acme/docs/blob/main/docs/setup.md",
+			EditLabel: "Edit on GitHub",
+		})
+	})
+
+	t.Run("Custom patterns are used for other hosts", func(t *testing.T) {
+		site.Repository.URL = "https://git.example.com/docs"
+		site.Repository.EditURL = "{url}/_edit/{branch}/{path}"
+		site.Repository.SourceURL = "{url}/src/{path}#{start}"
+
+		links := newRepository(site).links(page)
+		assert.Contains(t, links, OriginLink{
+			File:      "src/main.go",
+			Lines:     "3-8",
+			Edit:      "https://git.example.com/docs/_edit/main/src/main.go",
+			View:      "https://git.example.com/docs/src/src/main.go#3",
+			EditLabel: "Edit this page",
+		})
+	})
+
+	t.Run("No links without a repository", func(t *testing.T) {
+		site.Repository = RepositoryConfig{}
+		assert.Empty(t, newRepository(site).links(page))
+	})
+}
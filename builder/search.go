@@ -0,0 +1,125 @@
+package builder
+
+import (
+	"encoding/json"
+	"html"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	. "github.com/patrixr/auteur/core"
+)
+
+const SearchIndexFile = "search.json"
+
+var (
+	headingRexp    = regexp.MustCompile(`(?is)<h[1-6][^>]*>(.*?)</h[1-6]>`)
+	ignoredRexp    = regexp.MustCompile(`(?is)<(script|style)[^>]*>.*?</(script|style)>`)
+	tagRexp        = regexp.MustCompile(`(?s)<[^>]*>`)
+	whitespaceRexp = regexp.MustCompile(`\s+`)
+)
+
+// SearchEntry is a single page of the search index
+type SearchEntry struct {
+	Title    string   `json:"title"`
+	Href     string   `json:"href"`
+	Fragment string   `json:"fragment"`
+	Headings []string `json:"headings"`
+	Body     string   `json:"body"`
+}
+
+// BuildSearchIndex returns an entry for every page of the site with content
+func (builder DefaultBuilder) BuildSearchIndex(site *Auteur) ([]SearchEntry, error) {
+	entries := []SearchEntry{}
+	webroot := strings.TrimRight(site.Webroot, "/")
+
+	var traverse func(page *Auteur) error
+	traverse = func(page *Auteur) error {
+		if len(page.Content) > 0 {
+			buffer, err := builder.GetHTML(page)
+			if err != nil {
+				return err
+			}
+
+			href, fragment := pageURLs(webroot, page)
+
+			entries = append(entries, SearchEntry{
+				Title:    page.Title,
+				Href:     href,
+				Fragment: fragment,
+				Headings: extractHeadings(buffer.String()),
+				Body:     htmlToText(buffer.String()),
+			})
+		}
+
+		for _, child := range page.Children() {
+			if err := traverse(child); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := traverse(site); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// WriteSearchIndex writes the JSON search index at the root of the output folder
+func (builder DefaultBuilder) WriteSearchIndex(site *Auteur, outfolder string) error {
+	entries, err := builder.BuildSearchIndex(site)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(outfolder, SearchIndexFile), data, 0644)
+}
+
+// pageURLs returns the url of a page and of its fragment, following
+// the same conventions as the navigation tree of the default template
+func pageURLs(webroot string, page *Auteur) (string, string) {
+	var href string
+	var err error
+
+	if page.IsRoot() || page.HasChildren() {
+		href, err = url.JoinPath(webroot+"/", page.Href(), "index")
+	} else {
+		href, err = url.JoinPath(webroot+"/", page.Href())
+	}
+
+	if err != nil {
+		href = page.Href()
+	}
+
+	return href, href + ".frag"
+}
+
+func extractHeadings(text string) []string {
+	headings := []string{}
+
+	for _, match := range headingRexp.FindAllStringSubmatch(text, -1) {
+		heading := htmlToText(match[1])
+		if heading != "" {
+			headings = append(headings, heading)
+		}
+	}
+
+	return headings
+}
+
+func htmlToText(text string) string {
+	text = ignoredRexp.ReplaceAllString(text, " ")
+	text = tagRexp.ReplaceAllString(text, " ")
+	text = html.UnescapeString(text)
+	return strings.TrimSpace(whitespaceRexp.ReplaceAllString(text, " "))
+}
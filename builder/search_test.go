@@ -0,0 +1,61 @@
+package builder
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/patrixr/auteur/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchIndex(t *testing.T) {
+	outfolder := t.TempDir()
+
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+	site.Webroot = "/docs/"
+
+	site.AddContent(linkContent{path: []string{}, html: `<p>Welcome</p>`})
+	site.AddContent(linkContent{path: []string{"guides", "setup"}, html: `
+		<h1 id="setup">Setup <code>auteur</code></h1>
+		<style>.hidden { display: none }</style>
+		<p>Install &amp; run</p>
+		<script>console.log("not indexed")</script>
+		<h2 id="linux">On Linux</h2>
+	`})
+	site.AddContent(linkContent{path: []string{"guides", "setup", "windows"}, html: `<p>Windows</p>`})
+
+	assert.NoError(t, NewDefaultBuilder().Render(site, outfolder))
+
+	data, err := os.ReadFile(filepath.Join(outfolder, SearchIndexFile))
+	assert.NoError(t, err)
+
+	var entries []SearchEntry
+	assert.NoError(t, json.Unmarshal(data, &entries))
+
+	index := map[string]SearchEntry{}
+	for _, entry := range entries {
+		index[entry.Title] = entry
+	}
+
+	t.Run("Pages with content are indexed", func(t *testing.T) {
+		assert.Len(t, entries, 3)
+		assert.NotContains(t, index, "guides")
+	})
+
+	t.Run("Urls include the webroot", func(t *testing.T) {
+		assert.Equal(t, "/docs/index", index[site.Title].Href)
+		assert.Equal(t, "/docs/guides/setup/index", index["setup"].Href)
+		assert.Equal(t, "/docs/guides/setup/index.frag", index["setup"].Fragment)
+		assert.Equal(t, "/docs/guides/setup/windows", index["windows"].Href)
+	})
+
+	t.Run("Headings and text are extracted from the html", func(t *testing.T) {
+		setup := index["setup"]
+		assert.Equal(t, []string{"Setup auteur", "On Linux"}, setup.Headings)
+		assert.Equal(t, "Setup auteur Install & run On Linux", setup.Body)
+		assert.Empty(t, index["windows"].Headings)
+	})
+}
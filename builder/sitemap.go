@@ -0,0 +1,223 @@
+package builder
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+)
+
+const (
+	SitemapFile = "sitemap.xml"
+	RobotsFile  = "robots.txt"
+)
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	Lastmod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapRef struct {
+	Loc string `xml:"loc"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapRef `xml:"sitemap"`
+}
+
+// BuildSitemap returns the sitemap of the site, listing every page with content
+func (builder DefaultBuilder) BuildSitemap(site *Auteur) ([]byte, error) {
+	urlset := sitemapURLSet{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+
+	var traverse func(page *Auteur)
+	traverse = func(page *Auteur) {
+		if len(page.Content) > 0 || page.IsRoot() {
+			entry := sitemapURL{Loc: absoluteURL(site, page)}
+
+			if date, ok := pageDate(page); ok {
+				entry.Lastmod = date.Format("2006-01-02")
+			}
+
+			urlset.URLs = append(urlset.URLs, entry)
+		}
+
+		for _, child := range page.Children() {
+			traverse(child)
+		}
+	}
+
+	traverse(site)
+
+	data, err := xml.MarshalIndent(urlset, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+// WriteSitemap writes the sitemap at the root of the output folder
+// Sitemaps require absolute urls, so nothing is written without a baseURL
+func (builder DefaultBuilder) WriteSitemap(site *Auteur, outfolder string) error {
+	if site.BaseURL == "" {
+		return nil
+	}
+
+	data, err := builder.BuildSitemap(site)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(outfolder, SitemapFile), data, 0644)
+}
+
+// BuildRobots returns the content of the robots.txt file of the site
+func (builder DefaultBuilder) BuildRobots(site *Auteur) string {
+	sitemap := ""
+	if site.BaseURL != "" {
+		sitemap = sitemapURLOf(site)
+	}
+
+	return buildRobots(disallowedPaths(site), sitemap)
+}
+
+// WriteRobots writes the robots.txt file at the root of the output folder
+func (builder DefaultBuilder) WriteRobots(site *Auteur, outfolder string) error {
+	return os.WriteFile(filepath.Join(outfolder, RobotsFile), []byte(builder.BuildRobots(site)), 0644)
+}
+
+// BuildSitemapIndex returns a sitemap index referencing the sitemap of every site
+// and of their translations, e.g the versions of the documentation
+func BuildSitemapIndex(sites []*Auteur) ([]byte, error) {
+	index := sitemapIndex{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+
+	for _, site := range withTranslations(sites) {
+		if site.BaseURL != "" {
+			index.Sitemaps = append(index.Sitemaps, sitemapRef{Loc: sitemapURLOf(site)})
+		}
+	}
+
+	data, err := xml.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+// WriteRootSiteFiles writes a robots.txt file and a sitemap index at the root of the output folder,
+// for sites rendered into subfolders of it (locales or versions).
+// Crawlers only read the robots.txt file at the root of the host
+func WriteRootSiteFiles(config AuteurConfig, sites []*Auteur, outfolder string) error {
+	disallow := []string{}
+	for _, site := range withTranslations(sites) {
+		disallow = append(disallow, disallowedPaths(site)...)
+	}
+
+	sitemap := ""
+
+	if config.BaseURL != "" {
+		sitemap = strings.TrimRight(config.BaseURL, "/") + strings.TrimRight(config.Webroot, "/") + "/" + SitemapFile
+
+		data, err := BuildSitemapIndex(sites)
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(filepath.Join(outfolder, SitemapFile), data, 0644); err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(filepath.Join(outfolder, RobotsFile), []byte(buildRobots(disallow, sitemap)), 0644)
+}
+
+// buildRobots returns the content of a robots.txt file disallowing the given paths
+func buildRobots(disallow []string, sitemap string) string {
+	var sb strings.Builder
+
+	sb.WriteString("User-agent: *\n")
+
+	if len(disallow) == 0 {
+		sb.WriteString("Disallow:\n")
+	}
+
+	for _, path := range disallow {
+		sb.WriteString(fmt.Sprintf("Disallow: %s\n", path))
+	}
+
+	if sitemap != "" {
+		sb.WriteString(fmt.Sprintf("\nSitemap: %s\n", sitemap))
+	}
+
+	return sb.String()
+}
+
+// disallowedPaths returns the paths of robots.disallow, prefixed with the webroot of the site
+func disallowedPaths(site *Auteur) []string {
+	webroot := strings.TrimRight(site.Webroot, "/")
+	paths := []string{}
+
+	for _, path := range site.Robots.Disallow {
+		paths = append(paths, webroot+"/"+strings.TrimLeft(path, "/"))
+	}
+
+	return paths
+}
+
+// sitemapURLOf returns the absolute url of the sitemap of the site
+func sitemapURLOf(site *Auteur) string {
+	return strings.TrimRight(site.BaseURL, "/") + strings.TrimRight(site.Webroot, "/") + "/" + SitemapFile
+}
+
+// withTranslations returns the sites along with the sites of their other locales
+func withTranslations(sites []*Auteur) []*Auteur {
+	all := []*Auteur{}
+
+	for _, site := range sites {
+		if translations := site.Translations(); len(translations) > 0 {
+			all = append(all, translations...)
+		} else {
+			all = append(all, site)
+		}
+	}
+
+	return all
+}
+
+// absoluteURL returns the url of a page, including the baseURL of the site
+func absoluteURL(site *Auteur, page *Auteur) string {
+	webroot := strings.TrimRight(site.Webroot, "/")
+	baseURL := strings.TrimRight(site.BaseURL, "/")
+
+	if page.IsRoot() {
+		return baseURL + webroot + "/"
+	}
+
+	href, _ := pageURLs(webroot, page)
+	return baseURL + href
+}
+
+// pageDate returns the date set in the frontmatter of the page, if any
+func pageDate(page *Auteur) (time.Time, bool) {
+	for _, content := range page.Content {
+		if date, ok := ParseDate(content.Meta()["date"]); ok {
+			return date, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,143 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteFiles(t *testing.T) {
+	outfolder := t.TempDir()
+
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+	site.BaseURL = "https://example.com/"
+	site.Webroot = "/docs"
+	site.Robots.Disallow = []string{"private"}
+
+	site.AddContent(linkContent{path: []string{}, html: `<p>Home</p>`})
+	site.AddContent(linkContent{path: []string{"guides", "setup"}, html: `<p>Setup</p>`})
+	site.AddContent(linkContent{path: []string{"news", "first"}, html: `<p>First post</p>`, meta: Metadata{"date": "2024-01-02"}})
+	site.AddContent(linkContent{path: []string{"news", "second"}, html: `<p>Second post</p>`, meta: Metadata{"date": "2024-03-04T10:00:00Z"}})
+
+	assert.NoError(t, NewDefaultBuilder().Render(site, outfolder))
+
+	t.Run("Sitemap lists every page with absolute urls", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(outfolder, SitemapFile))
+		assert.NoError(t, err)
+
+		sitemap := string(data)
+		assert.Contains(t, sitemap, "<loc>https://example.com/docs/</loc>")
+		assert.Contains(t, sitemap, "<loc>https://example.com/docs/guides/setup</loc>")
+		assert.Contains(t, sitemap, "<loc>https://example.com/docs/news/first</loc>")
+		assert.Contains(t, sitemap, "<lastmod>2024-01-02</lastmod>")
+		assert.NotContains(t, sitemap, "https://example.com/docs/guides/index")
+	})
+
+	t.Run("Robots.txt references the sitemap", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(outfolder, RobotsFile))
+		assert.NoError(t, err)
+
+		robots := string(data)
+		assert.Contains(t, robots, "Disallow: /docs/private\n")
+		assert.Contains(t, robots, "Sitemap: https://example.com/docs/sitemap.xml\n")
+	})
+
+	t.Run("Feed lists dated pages, most recent first", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(outfolder, FeedFile))
+		assert.NoError(t, err)
+
+		feed := string(data)
+		assert.Contains(t, feed, `<link href="https://example.com/docs/feed.xml" rel="self"></link>`)
+		assert.Contains(t, feed, "<updated>2024-03-04T10:00:00Z</updated>")
+		assert.NotContains(t, feed, "Setup")
+		assert.Less(t, strings.Index(feed, "<title>second</title>"), strings.Index(feed, "<title>first</title>"))
+
+		data, err = os.ReadFile(filepath.Join(outfolder, "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `href="/docs/feed.xml"`)
+	})
+
+	t.Run("Feeds name their author", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(outfolder, FeedFile))
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "<author>\n    <name>"+site.Title+"</name>\n  </author>")
+
+		site.Author = "Jane Doe"
+		defer func() { site.Author = "" }()
+
+		data, err = DefaultBuilder{}.BuildFeed(site)
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "<name>Jane Doe</name>")
+	})
+
+	t.Run("Sites without dated pages have no feed", func(t *testing.T) {
+		outfolder := t.TempDir()
+
+		site, err := NewAuteur()
+		assert.NoError(t, err)
+		site.BaseURL = "https://example.com/"
+		site.AddContent(linkContent{path: []string{}, html: `<p>Home</p>`})
+
+		assert.NoError(t, NewDefaultBuilder().Render(site, outfolder))
+		assert.NoFileExists(t, filepath.Join(outfolder, FeedFile))
+
+		data, err := os.ReadFile(filepath.Join(outfolder, "index.html"))
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), FeedFile)
+	})
+
+	t.Run("Sitemap and feed require a baseURL", func(t *testing.T) {
+		outfolder := t.TempDir()
+		site.BaseURL = ""
+
+		assert.NoError(t, NewDefaultBuilder().Render(site, outfolder))
+		assert.NoFileExists(t, filepath.Join(outfolder, SitemapFile))
+		assert.NoFileExists(t, filepath.Join(outfolder, FeedFile))
+		assert.FileExists(t, filepath.Join(outfolder, RobotsFile))
+	})
+
+	t.Run("Sites built into subfolders are listed at the root", func(t *testing.T) {
+		outfolder := t.TempDir()
+
+		config, err := DetectConfig()
+		assert.NoError(t, err)
+		config.BaseURL = "https://example.com"
+		config.Webroot = "/docs"
+		config.Robots.Disallow = []string{"private"}
+
+		sites := []*Auteur{}
+		for _, lang := range []string{"en", "fr"} {
+			site := NewAuteurFromConfig(config.ForLocale(lang))
+			site.AddContent(linkContent{path: []string{}, html: `<p>Home</p>`})
+			sites = append(sites, site)
+		}
+
+		for _, site := range sites {
+			site.UseTranslations(sites)
+		}
+
+		assert.NoError(t, WriteRootSiteFiles(config, sites[:1], outfolder))
+
+		data, err := os.ReadFile(filepath.Join(outfolder, SitemapFile))
+		assert.NoError(t, err)
+
+		index := string(data)
+		assert.Contains(t, index, "<sitemapindex")
+		assert.Contains(t, index, "<loc>https://example.com/docs/en/sitemap.xml</loc>")
+		assert.Contains(t, index, "<loc>https://example.com/docs/fr/sitemap.xml</loc>")
+
+		data, err = os.ReadFile(filepath.Join(outfolder, RobotsFile))
+		assert.NoError(t, err)
+
+		robots := string(data)
+		assert.Contains(t, robots, "Disallow: /docs/en/private\n")
+		assert.Contains(t, robots, "Disallow: /docs/fr/private\n")
+		assert.Contains(t, robots, "Sitemap: https://example.com/docs/sitemap.xml\n")
+	})
+}
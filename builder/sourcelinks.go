@@ -0,0 +1,63 @@
+package builder
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+)
+
+var sourceLinkRexp = regexp.MustCompile(`href="(` + SourceLinkScheme + `:[^"]*)"`)
+
+// sourceIndex maps every ingested source file to the first page it contributed to
+type sourceIndex map[string]*Auteur
+
+func newSourceIndex(site *Auteur) sourceIndex {
+	index := sourceIndex{}
+
+	var traverse func(page *Auteur)
+	traverse = func(page *Auteur) {
+		for _, source := range page.Sources() {
+			if _, ok := index[source]; !ok {
+				index[source] = page
+			}
+		}
+
+		for _, child := range page.Children() {
+			traverse(child)
+		}
+	}
+
+	traverse(site.Root())
+	return index
+}
+
+// resolve replaces source link placeholders with the url of the page the
+// linked file ended up in. Links to files that weren't ingested are restored as is
+func (index sourceIndex) resolve(site *Auteur, text string) string {
+	webroot := strings.TrimRight(site.Root().Webroot, "/")
+
+	return sourceLinkRexp.ReplaceAllStringFunc(text, func(match string) string {
+		placeholder := html.UnescapeString(sourceLinkRexp.FindStringSubmatch(match)[1])
+
+		file, fragment, original, ok := ParseSourceLink(placeholder)
+		if !ok {
+			return match
+		}
+
+		href := original
+
+		if page, found := index[file]; found {
+			href, _ = pageURLs(webroot, page)
+			if fragment != "" {
+				href += "#" + fragment
+			}
+		} else {
+			LogDebug("Link to a file without page", "file", file, "page", site.Href())
+		}
+
+		return `href="` + html.EscapeString(href) + `"`
+	})
+}
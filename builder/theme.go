@@ -0,0 +1,174 @@
+package builder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+
+	. "github.com/patrixr/auteur/common"
+)
+
+const templateExt = ".tmpl"
+
+// Theme holds the templates and static files used to render a site.
+// The embedded default theme is always loaded first, a local theme folder
+// can then override its templates and static files or add new ones
+type Theme struct {
+	Folder    string
+	Templates *template.Template
+}
+
+// LoadTheme parses the default templates, followed by the templates of the
+// theme folder if one is provided. Templates are named after their file name,
+// so a local page.html.tmpl replaces the default one.
+// Partials defined with {{ define "..." }} can be overridden the same way
+func LoadTheme(folder string) (*Theme, error) {
+	templates, err := template.New("").Funcs(templateFuncs).ParseFS(tmplFS, "assets/**/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	theme := &Theme{
+		Folder:    folder,
+		Templates: templates,
+	}
+
+	if folder == "" {
+		return theme, nil
+	}
+
+	files, err := theme.files(func(path string) bool {
+		return strings.HasSuffix(path, templateExt)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) > 0 {
+		if _, err := templates.ParseFiles(files...); err != nil {
+			return nil, err
+		}
+	}
+
+	return theme, nil
+}
+
+// CopyStatic copies every non-template file of the theme folder into the output folder,
+// keeping their relative paths. Files named like a default asset (e.g style.css) replace it
+func (theme *Theme) CopyStatic(outfolder string) error {
+	if theme.Folder == "" {
+		return nil
+	}
+
+	files, err := theme.files(func(path string) bool {
+		return !strings.HasSuffix(path, templateExt)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		rel, err := filepath.Rel(theme.Folder, file)
+		if err != nil {
+			return err
+		}
+
+		dest := filepath.Join(outfolder, rel)
+
+		if err := Mkdirp(filepath.Dir(dest)); err != nil {
+			return err
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(dest, data, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// embeddedSignature hashes the embedded assets, vendored libraries included.
+// They can't change while the binary runs, so they are only hashed once
+var embeddedSignature = sync.OnceValue(func() []byte {
+	hash := sha256.New()
+
+	fs.WalkDir(tmplFS, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		data, err := tmplFS.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		hash.Write([]byte(path))
+		hash.Write(data)
+		return nil
+	})
+
+	return hash.Sum(nil)
+})
+
+// Signature returns a hash of the embedded assets and of the theme folder,
+// used to invalidate incremental builds whenever the theme changes
+func (theme *Theme) Signature() string {
+	hash := sha256.New()
+	hash.Write(embeddedSignature())
+
+	if files, err := theme.files(func(string) bool { return true }); err == nil {
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				continue
+			}
+
+			hash.Write([]byte(file))
+			hash.Write(data)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// files lists the files of the theme folder matching the filter, hidden files are skipped
+func (theme *Theme) files(filter func(path string) bool) ([]string, error) {
+	files := []string{}
+
+	if theme.Folder == "" {
+		return files, nil
+	}
+
+	err := filepath.WalkDir(theme.Folder, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(entry.Name(), ".") && path != theme.Folder {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !entry.IsDir() && filter(path) {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+
+	return files, err
+}
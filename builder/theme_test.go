@@ -0,0 +1,87 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/patrixr/auteur/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTheme(t *testing.T) {
+	themedir := t.TempDir()
+
+	files := map[string]string{
+		"tree-item.html.tmpl": `{{ define "tree-item" }}<wa-tree-item class="custom-item"></wa-tree-item>{{ end }}`,
+		"style.css":           "body { color: red }",
+		"images/logo.svg":     "<svg></svg>",
+		".hidden/notes.txt":   "",
+	}
+
+	for file, content := range files {
+		os.MkdirAll(filepath.Join(themedir, filepath.Dir(file)), 0755)
+		os.WriteFile(filepath.Join(themedir, file), []byte(content), 0644)
+	}
+
+	render := func() string {
+		outfolder := t.TempDir()
+
+		site, err := NewAuteur()
+		assert.NoError(t, err)
+		site.ThemeDir = themedir
+		site.AddContent(linkContent{path: []string{}, html: `<p>Home</p>`})
+		site.AddContent(linkContent{path: []string{"guides", "setup"}, html: `<p>Setup</p>`})
+
+		assert.NoError(t, NewDefaultBuilder().Render(site, outfolder))
+		return outfolder
+	}
+
+	outfolder := render()
+
+	t.Run("Partials of the theme replace the default ones", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(outfolder, "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `class="custom-item"`)
+	})
+
+	t.Run("Missing templates fall back to the default ones", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(outfolder, "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "<p>Home</p>")
+		assert.Contains(t, string(data), "htmx.min.js")
+	})
+
+	t.Run("Static files of the theme are copied over the default ones", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(outfolder, "style.css"))
+		assert.NoError(t, err)
+		assert.Equal(t, "body { color: red }", string(data))
+
+		assert.FileExists(t, filepath.Join(outfolder, "script.js"))
+		assert.FileExists(t, filepath.Join(outfolder, "images", "logo.svg"))
+		assert.NoFileExists(t, filepath.Join(outfolder, "tree-item.html.tmpl"))
+		assert.NoDirExists(t, filepath.Join(outfolder, ".hidden"))
+	})
+
+	t.Run("Pages of the theme replace the default one", func(t *testing.T) {
+		os.WriteFile(filepath.Join(themedir, "page.html.tmpl"), []byte(`<main>{{ .Title }}: {{ .Fragment }}</main>`), 0644)
+		defer os.Remove(filepath.Join(themedir, "page.html.tmpl"))
+
+		data, err := os.ReadFile(filepath.Join(render(), "guides", "setup.html"))
+		assert.NoError(t, err)
+		assert.Equal(t, "<main>setup: <p>Setup</p></main>", string(data))
+	})
+
+	t.Run("Signatures change with the files of the theme", func(t *testing.T) {
+		theme, err := LoadTheme(themedir)
+		assert.NoError(t, err)
+		before := theme.Signature()
+
+		os.WriteFile(filepath.Join(themedir, "style.css"), []byte("body { color: blue }"), 0644)
+		assert.NotEqual(t, before, theme.Signature())
+
+		defaults, err := LoadTheme("")
+		assert.NoError(t, err)
+		assert.NotEqual(t, theme.Signature(), defaults.Signature())
+	})
+}
@@ -0,0 +1,77 @@
+package builder
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Headings deeper than this level are left out of the table of contents
+const tocMaxLevel = 3
+
+var headingIDRexp = regexp.MustCompile(`(?is)<h([1-6])([^>]*?)\sid="([^"]*)"([^>]*)>(.*?)</h[1-6]>`)
+
+// TocEntry is a heading of a page, as listed in its table of contents
+type TocEntry struct {
+	Level int
+	ID    string
+	Title string
+	// Indentation of the entry, relative to the top level heading of the page
+	Depth int
+}
+
+// uniqueHeadingIDs de-duplicates the ids of the headings of a page.
+// Each chunk of content generates its ids independently, so the same heading
+// appearing in two chunks gets a numbered suffix, in order of appearance
+func uniqueHeadingIDs(html string) string {
+	seen := map[string]bool{}
+
+	return headingIDRexp.ReplaceAllStringFunc(html, func(match string) string {
+		parts := headingIDRexp.FindStringSubmatch(match)
+		id := parts[3]
+
+		unique := id
+		for i := 1; seen[unique]; i++ {
+			unique = fmt.Sprintf("%s-%d", id, i)
+		}
+		seen[unique] = true
+
+		if unique == id {
+			return match
+		}
+
+		return fmt.Sprintf(`<h%s%s id="%s"%s>%s</h%s>`, parts[1], parts[2], unique, parts[4], parts[5], parts[1])
+	})
+}
+
+// extractToc returns the headings of a page that carry an id
+func extractToc(html string) []TocEntry {
+	toc := []TocEntry{}
+	minLevel := tocMaxLevel
+
+	for _, match := range headingIDRexp.FindAllStringSubmatch(html, -1) {
+		level := int(match[1][0] - '0')
+
+		if level > tocMaxLevel {
+			continue
+		}
+
+		title := htmlToText(match[5])
+		if title == "" {
+			continue
+		}
+
+		minLevel = min(minLevel, level)
+
+		toc = append(toc, TocEntry{
+			Level: level,
+			ID:    match[3],
+			Title: title,
+		})
+	}
+
+	for i := range toc {
+		toc[i].Depth = toc[i].Level - minLevel
+	}
+
+	return toc
+}
@@ -0,0 +1,34 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableOfContents(t *testing.T) {
+	html := `<h1 id="guide">Guide</h1>
+<p>Intro</p>
+<h2 id="setup">Setup</h2>
+<h4 id="details">Details</h4>
+<h1 id="guide">Guide</h1>
+<h2 id="setup">Setup &amp; more</h2>`
+
+	t.Run("Heading ids are unique across content chunks", func(t *testing.T) {
+		unique := uniqueHeadingIDs(html)
+		assert.Contains(t, unique, `<h1 id="guide">Guide</h1>`)
+		assert.Contains(t, unique, `<h1 id="guide-1">Guide</h1>`)
+		assert.Contains(t, unique, `<h2 id="setup">Setup</h2>`)
+		assert.Contains(t, unique, `<h2 id="setup-1">Setup &amp; more</h2>`)
+	})
+
+	t.Run("Toc lists headings up to level 3", func(t *testing.T) {
+		toc := extractToc(uniqueHeadingIDs(html))
+		assert.Equal(t, []TocEntry{
+			{Level: 1, ID: "guide", Title: "Guide", Depth: 0},
+			{Level: 2, ID: "setup", Title: "Setup", Depth: 1},
+			{Level: 1, ID: "guide-1", Title: "Guide", Depth: 0},
+			{Level: 2, ID: "setup-1", Title: "Setup & more", Depth: 1},
+		}, toc)
+	})
+}
@@ -0,0 +1,261 @@
+package builder
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+)
+
+// VendorFolder is the folder, relative to the output folder,
+// that third-party assets are copied into when building offline
+const VendorFolder = "vendor"
+
+// Location of the third-party assets inside of the embedded filesystem
+const vendorEmbedRoot = "assets/thirdparty"
+
+// VendorPackage is a third-party library used by the default theme.
+// Online builds reference it from its CDN, offline builds embed the
+// files extracted from its npm tarball into the binary
+type VendorPackage struct {
+	Name    string
+	CDN     string
+	Tarball string
+	// Folder of the tarball the CDN paths are relative to
+	Root string
+	// Files or folders (relative to Root) to extract
+	Include []string
+}
+
+var vendorPackages = []VendorPackage{
+	{
+		Name:    "htmx",
+		CDN:     "https://unpkg.com/htmx.org@1.9.6/dist",
+		Tarball: "https://registry.npmjs.org/htmx.org/-/htmx.org-1.9.6.tgz",
+		Root:    "package/dist",
+		Include: []string{"htmx.min.js"},
+	},
+	{
+		Name:    "webawesome",
+		CDN:     "https://early.webawesome.com/webawesome@3.0.0-alpha.9/dist",
+		Tarball: "https://registry.npmjs.org/@awesome.me/webawesome/-/webawesome-3.0.0-alpha.9.tgz",
+		Root:    "package/dist",
+		Include: []string{""},
+	},
+	{
+		Name:    "fontawesome",
+		CDN:     "https://cdn.jsdelivr.net/npm/@fortawesome/fontawesome-free@6.5.1/svgs",
+		Tarball: "https://registry.npmjs.org/@fortawesome/fontawesome-free/-/fontawesome-free-6.5.1.tgz",
+		Root:    "package/svgs",
+		Include: []string{"solid", "regular", "brands"},
+	},
+	{
+		Name:    "mermaid",
+		CDN:     "https://cdn.jsdelivr.net/npm/mermaid@11.4.1/dist",
+		Tarball: "https://registry.npmjs.org/mermaid/-/mermaid-11.4.1.tgz",
+		Root:    "package/dist",
+		Include: []string{"mermaid.min.js"},
+	},
+}
+
+// Placeholder urls of third-party files, see VendorScheme
+var vendorPlaceholderRexp = regexp.MustCompile(VendorScheme + `:([\w-]+)/([^"'\s]+)`)
+
+// VendorURL returns the url of a file of a third-party package,
+// either from its CDN or from the local vendor folder when building offline
+func VendorURL(webroot string, offline bool, pkg string, file string) (string, error) {
+	for _, vendor := range vendorPackages {
+		if vendor.Name != pkg {
+			continue
+		}
+
+		if offline {
+			return url.JoinPath(strings.TrimRight(webroot, "/")+"/", VendorFolder, pkg, file)
+		}
+
+		return url.JoinPath(vendor.CDN, file)
+	}
+
+	return "", fmt.Errorf("unknown vendor package %s", pkg)
+}
+
+// resolveVendorURLs replaces the placeholder urls of third-party files found in the html,
+// e.g the mermaid script added to pages with diagrams
+func resolveVendorURLs(site *Auteur, text string) string {
+	return vendorPlaceholderRexp.ReplaceAllStringFunc(text, func(match string) string {
+		parts := vendorPlaceholderRexp.FindStringSubmatch(match)
+
+		href, err := VendorURL(site.Root().Webroot, site.Offline, parts[1], parts[2])
+		if err != nil {
+			return match
+		}
+
+		return href
+	})
+}
+
+// CheckVendorAssets returns an error if the third-party assets weren't embedded into the binary,
+// which happens when it is built without running `make vendor` first (e.g with go install)
+func CheckVendorAssets() error {
+	missing := []string{}
+
+	for _, vendor := range vendorPackages {
+		if _, err := fs.Stat(tmplFS, path.Join(vendorEmbedRoot, vendor.Name)); err != nil {
+			missing = append(missing, vendor.Name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("offline builds need the vendored assets (%s), which are missing from this binary. Run `make vendor` before building it, or set offline to false", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// CopyVendorAssets copies the embedded third-party assets into the output folder
+func (t DefaultBuilder) CopyVendorAssets(outfolder string) error {
+	if err := CheckVendorAssets(); err != nil {
+		return err
+	}
+
+	for _, vendor := range vendorPackages {
+		src := path.Join(vendorEmbedRoot, vendor.Name)
+
+		err := fs.WalkDir(tmplFS, src, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+
+			rel := strings.TrimPrefix(file, vendorEmbedRoot+"/")
+			dest := filepath.Join(outfolder, VendorFolder, filepath.FromSlash(rel))
+
+			if err := Mkdirp(filepath.Dir(dest)); err != nil {
+				return err
+			}
+
+			data, err := tmplFS.ReadFile(file)
+			if err != nil {
+				return err
+			}
+
+			return os.WriteFile(dest, data, 0644)
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DownloadVendorAssets fetches every third-party package from npm and extracts
+// the files used by the default theme into the destination folder
+func DownloadVendorAssets(dest string) error {
+	for _, vendor := range vendorPackages {
+		Log("Downloading", "package", vendor.Name, "url", vendor.Tarball)
+
+		if err := Rmdir(filepath.Join(dest, vendor.Name)); err != nil {
+			return err
+		}
+
+		if err := downloadVendorPackage(vendor, filepath.Join(dest, vendor.Name)); err != nil {
+			return fmt.Errorf("failed to download %s: %w", vendor.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func downloadVendorPackage(vendor VendorPackage, dest string) error {
+	resp, err := http.Get(vendor.Tarball)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	archive := tar.NewReader(gz)
+
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		rel, ok := vendor.includes(header.Name)
+		if !ok {
+			continue
+		}
+
+		target := filepath.Join(dest, filepath.FromSlash(rel))
+
+		if err := Mkdirp(filepath.Dir(target)); err != nil {
+			return err
+		}
+
+		out, err := os.Create(target)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(out, archive)
+		out.Close()
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// includes returns the path of a tarball entry relative to the package root,
+// if the entry is part of the files to extract
+func (vendor VendorPackage) includes(name string) (string, bool) {
+	name = path.Clean(name)
+
+	rel, ok := strings.CutPrefix(name, vendor.Root+"/")
+	if !ok {
+		return "", false
+	}
+
+	// Skip source maps and type definitions
+	if strings.HasSuffix(rel, ".map") || strings.HasSuffix(rel, ".d.ts") {
+		return "", false
+	}
+
+	for _, include := range vendor.Include {
+		if include == "" || rel == include || strings.HasPrefix(rel, include+"/") {
+			return rel, true
+		}
+	}
+
+	return "", false
+}
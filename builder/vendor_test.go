@@ -0,0 +1,62 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVendorAssets(t *testing.T) {
+	t.Run("Offline builds reference the vendor folder", func(t *testing.T) {
+		href, err := VendorURL("/docs", true, "htmx", "htmx.min.js")
+		assert.NoError(t, err)
+		assert.Equal(t, "/docs/vendor/htmx/htmx.min.js", href)
+
+		href, err = VendorURL("/docs", false, "htmx", "htmx.min.js")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://unpkg.com/htmx.org@1.9.6/dist/htmx.min.js", href)
+	})
+
+	t.Run("Diagrams load mermaid from the vendor folder when offline", func(t *testing.T) {
+		diagram, err := MarkdownToHTML([]byte("```mermaid\ngraph TD; A-->B\n```"))
+		assert.NoError(t, err)
+
+		site, err := NewAuteur()
+		assert.NoError(t, err)
+		site.Webroot = "/docs"
+		site.AddContent(linkContent{path: []string{}, html: diagram})
+
+		online, err := DefaultBuilder{}.GetHTML(site)
+		assert.NoError(t, err)
+		assert.Contains(t, online.String(), `<script src="https://cdn.jsdelivr.net/npm/mermaid@11.4.1/dist/mermaid.min.js">`)
+
+		site.Offline = true
+
+		offline, err := DefaultBuilder{}.GetHTML(site)
+		assert.NoError(t, err)
+		assert.Contains(t, offline.String(), `<script src="/docs/vendor/mermaid/mermaid.min.js">`)
+		assert.NotContains(t, offline.String(), "cdn.jsdelivr.net")
+	})
+
+	t.Run("Offline builds fail before writing anything when the assets are missing", func(t *testing.T) {
+		if CheckVendorAssets() == nil {
+			t.Skip("the vendored assets are embedded in this build")
+		}
+
+		outfolder := filepath.Join(t.TempDir(), "dist")
+
+		site, err := NewAuteur()
+		assert.NoError(t, err)
+		site.Offline = true
+		site.AddContent(linkContent{path: []string{}, html: `<p>Home</p>`})
+
+		assert.ErrorContains(t, NewDefaultBuilder().Render(site, outfolder), "make vendor")
+
+		_, err = os.Stat(outfolder)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
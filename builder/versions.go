@@ -0,0 +1,142 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+)
+
+const VersionsManifestFile = "versions.json"
+
+// VersionEntry describes one version of the site in the versions manifest
+type VersionEntry struct {
+	Name string `json:"name"`
+	Href string `json:"href"`
+	// Url of every page of the version, keyed by the path of the page
+	// relative to the version, e.g "/guides/setup"
+	Pages map[string]string `json:"pages"`
+}
+
+// BuildVersionsManifest returns an entry for each built version of the site
+func BuildVersionsManifest(sites []*Auteur) []VersionEntry {
+	entries := []VersionEntry{}
+
+	for _, site := range sites {
+		webroot := strings.TrimRight(site.Webroot, "/")
+		entry := VersionEntry{
+			Name:  site.Version,
+			Href:  webroot + "/",
+			Pages: map[string]string{},
+		}
+
+		var traverse func(page *Auteur)
+		traverse = func(page *Auteur) {
+			if len(page.Content) > 0 || page.IsRoot() {
+				href, _ := pageURLs(webroot, page)
+				entry.Pages[page.Href()] = href
+			}
+
+			for _, child := range page.Children() {
+				traverse(child)
+			}
+		}
+
+		traverse(site)
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// WriteVersions writes the versions manifest at the root of the output folder,
+// along with an index page redirecting to the default version.
+// Versions of the previous manifest that were not built again are removed
+func WriteVersions(sites []*Auteur, outfolder string) error {
+	if err := Mkdirp(outfolder); err != nil {
+		return err
+	}
+
+	entries := BuildVersionsManifest(sites)
+
+	if err := pruneVersions(outfolder, entries); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(outfolder, VersionsManifestFile), data, 0644); err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return WriteRedirect(outfolder, entries[0].Href)
+}
+
+// pruneVersions removes the folders of the versions listed in the current manifest
+// of the output folder which are no longer part of the site
+func pruneVersions(outfolder string, entries []VersionEntry) error {
+	data, err := os.ReadFile(filepath.Join(outfolder, VersionsManifestFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var previous []VersionEntry
+	if err := json.Unmarshal(data, &previous); err != nil {
+		LogWarn("Ignoring invalid versions manifest", "file", VersionsManifestFile, "error", err)
+		return nil
+	}
+
+	built := map[string]bool{}
+	for _, entry := range entries {
+		built[entry.Name] = true
+	}
+
+	for _, entry := range previous {
+		// Only folders directly inside of the output folder are removed
+		if built[entry.Name] || (VersionConfig{Name: entry.Name}).Validate() != nil {
+			continue
+		}
+
+		Log("Removing version", "version", entry.Name)
+
+		if err := Rmdir(filepath.Join(outfolder, entry.Name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteRedirect writes an index page at the root of the output folder redirecting to the given url
+func WriteRedirect(outfolder string, href string) error {
+	target := html.EscapeString(href)
+	redirect := fmt.Sprintf(`<!doctype html>
+<html>
+  <head>
+    <meta http-equiv="refresh" content="0; url=%s" />
+    <link rel="canonical" href="%s" />
+  </head>
+  <body>
+    <a href="%s">%s</a>
+  </body>
+</html>
+`, target, target, target, target)
+
+	return os.WriteFile(filepath.Join(outfolder, "index.html"), []byte(redirect), 0644)
+}
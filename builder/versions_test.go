@@ -0,0 +1,90 @@
+package builder
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/patrixr/auteur/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersions(t *testing.T) {
+	outfolder := t.TempDir()
+
+	config, err := DetectConfig()
+	assert.NoError(t, err)
+	config.Outfolder = outfolder
+	config.Webroot = "/docs"
+
+	latest := NewAuteurFromConfig(config.ForVersion(VersionConfig{Name: "v2"}))
+	latest.AddContent(linkContent{path: []string{"guides", "setup"}, html: `<p>Setup</p>`})
+	latest.AddContent(linkContent{path: []string{"guides", "setup", "linux"}, html: `<p>Linux</p>`})
+
+	legacy := NewAuteurFromConfig(config.ForVersion(VersionConfig{Name: "v1"}))
+	legacy.AddContent(linkContent{path: []string{"guides", "setup"}, html: `<p>Setup</p>`})
+
+	t.Run("Versions are built in their own folder", func(t *testing.T) {
+		assert.Equal(t, filepath.Join(outfolder, "v2"), latest.Outfolder)
+		assert.Equal(t, "/docs/v2", latest.Webroot)
+		assert.Equal(t, "/docs", latest.VersionsWebroot)
+		assert.Equal(t, "v2", latest.Version)
+	})
+
+	t.Run("Manifest maps pages to their url in each version", func(t *testing.T) {
+		assert.NoError(t, WriteVersions([]*Auteur{latest, legacy}, outfolder))
+
+		data, err := os.ReadFile(filepath.Join(outfolder, VersionsManifestFile))
+		assert.NoError(t, err)
+
+		var versions []VersionEntry
+		assert.NoError(t, json.Unmarshal(data, &versions))
+		assert.Len(t, versions, 2)
+
+		assert.Equal(t, "v2", versions[0].Name)
+		assert.Equal(t, "/docs/v2/", versions[0].Href)
+		assert.Equal(t, "/docs/v2/guides/setup/index", versions[0].Pages["/guides/setup"])
+		assert.Equal(t, "/docs/v1/guides/setup", versions[1].Pages["/guides/setup"])
+		assert.NotContains(t, versions[1].Pages, "/guides/setup/linux")
+	})
+
+	t.Run("Output root redirects to the default version", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(outfolder, "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `url=/docs/v2/`)
+	})
+
+	t.Run("Robots and sitemaps of the versions are listed at the root", func(t *testing.T) {
+		config.BaseURL = "https://example.com"
+		defer func() { config.BaseURL = "" }()
+
+		sites := []*Auteur{
+			NewAuteurFromConfig(config.ForVersion(VersionConfig{Name: "v2"})),
+			NewAuteurFromConfig(config.ForVersion(VersionConfig{Name: "v1"})),
+		}
+
+		assert.NoError(t, WriteRootSiteFiles(config, sites, outfolder))
+
+		data, err := os.ReadFile(filepath.Join(outfolder, SitemapFile))
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "<loc>https://example.com/docs/v2/sitemap.xml</loc>")
+		assert.Contains(t, string(data), "<loc>https://example.com/docs/v1/sitemap.xml</loc>")
+
+		data, err = os.ReadFile(filepath.Join(outfolder, RobotsFile))
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "Sitemap: https://example.com/docs/sitemap.xml\n")
+	})
+
+	t.Run("Versions no longer built are removed", func(t *testing.T) {
+		for _, name := range []string{"v1", "v2", "assets"} {
+			assert.NoError(t, os.MkdirAll(filepath.Join(outfolder, name), 0755))
+		}
+
+		assert.NoError(t, WriteVersions([]*Auteur{latest}, outfolder))
+
+		assert.DirExists(t, filepath.Join(outfolder, "v2"))
+		assert.DirExists(t, filepath.Join(outfolder, "assets"))
+		assert.NoDirExists(t, filepath.Join(outfolder, "v1"))
+	})
+}
@@ -0,0 +1,126 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes of the check command
+const (
+	checkPassed  = 0
+	checkFailed  = 1
+	checkAborted = 2
+)
+
+var checkFormat string
+var checkStrict bool
+
+// checkCmd represents the check command
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check the documentation for problems without building it",
+	Long: `Check the documentation for problems without building it.
+Every file is ingested with the configured processors, and the problems found
+are printed: invalid frontmatter and unknown frontmatter keys, malformed
+@auteur(...) arguments, pages defined by more than one file, empty pages
+and exclude patterns that don't match any file.
+
+The command exits with 1 when errors are found (or warnings, with --strict)
+and with 2 when the check itself couldn't run.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		SetQuiet(true)
+
+		config, err := loadConfig()
+		if err != nil {
+			LogError(err)
+			os.Exit(checkAborted)
+		}
+
+		problems, err := check(config)
+		if err != nil {
+			LogError(err)
+			os.Exit(checkAborted)
+		}
+
+		if err := printProblems(problems); err != nil {
+			LogError(err)
+			os.Exit(checkAborted)
+		}
+
+		if HasErrors(problems) || (checkStrict && len(problems) > 0) {
+			os.Exit(checkFailed)
+		}
+
+		os.Exit(checkPassed)
+	},
+}
+
+// check runs the checks over the root folder, once per locale for translated sites
+func check(config AuteurConfig) ([]Problem, error) {
+	if checkFormat != "text" && checkFormat != "json" {
+		return nil, fmt.Errorf("Unknown output format %s, expected text or json", checkFormat)
+	}
+
+	configs := []AuteurConfig{config}
+
+	if len(config.Locales) > 0 {
+		configs = []AuteurConfig{}
+		for _, lang := range config.Locales {
+			configs = append(configs, config.ForLocale(lang))
+		}
+	}
+
+	problems := []Problem{}
+
+	for _, config := range configs {
+		site, err := newSite(config)
+		if err != nil {
+			return nil, err
+		}
+
+		found, err := site.Check(site.Rootdir)
+		if err != nil {
+			return nil, err
+		}
+
+		// Problems of the sources are found again for every locale
+		for _, problem := range found {
+			if !slices.Contains(problems, problem) {
+				problems = append(problems, problem)
+			}
+		}
+	}
+
+	return problems, nil
+}
+
+// printProblems writes the problems to the standard output in the selected format
+func printProblems(problems []Problem) error {
+	if checkFormat == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(problems)
+	}
+
+	for _, problem := range problems {
+		fmt.Println(problem.String())
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+
+	checkCmd.Flags().StringVarP(&checkFormat, "format", "f", "text", "Output format, text or json")
+	checkCmd.Flags().BoolVar(&checkStrict, "strict", false, "Fail when warnings are found")
+}
@@ -0,0 +1,73 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/patrixr/auteur/core"
+)
+
+var configFile string
+
+// Values of the configuration settings given on the command line, by key
+var configOverrides = map[string][]string{}
+
+// Shorter flags of some configuration settings, by key
+var configAliases = map[string]string{
+	"outfolder": "out",
+}
+
+// configFlag collects the values of a configuration setting given on the command line,
+// they are applied once the configuration file and the environment have been read
+type configFlag struct {
+	field ConfigField
+}
+
+func (f *configFlag) String() string {
+	return strings.Join(configOverrides[f.field.Key], ",")
+}
+
+func (f *configFlag) Set(value string) error {
+	configOverrides[f.field.Key] = append(configOverrides[f.field.Key], value)
+	return nil
+}
+
+func (f *configFlag) Type() string {
+	return f.field.Type()
+}
+
+// loadConfig reads the configuration, overridden by the flags of the command line
+func loadConfig() (AuteurConfig, error) {
+	return LoadConfig(ConfigOptions{File: configFile, Overrides: configOverrides})
+}
+
+func init() {
+	flags := rootCmd.PersistentFlags()
+
+	flags.StringVarP(&configFile, "config", "c", "", "Configuration file to use (default is auteur.yml, auteur.yaml, auteur.json or auteur.toml)")
+
+	for _, field := range ConfigFields() {
+		value := &configFlag{field: field}
+		usage := fmt.Sprintf("Overrides the %s setting, also set by %s", field.Key, field.Env())
+		if field.Appends() {
+			usage = fmt.Sprintf("Adds to the %s setting of the configuration file (or to the defaults), also set by %s", field.Key, field.Env())
+		}
+
+		flag := flags.VarPF(value, field.Flag(), "", usage)
+
+		alias, ok := configAliases[field.Key]
+		if ok {
+			flags.Var(value, alias, "Shorthand for --"+field.Flag())
+		}
+
+		if field.Type() == "bool" {
+			flag.NoOptDefVal = "true"
+			if ok {
+				flags.Lookup(alias).NoOptDefVal = "true"
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"testing"
+
+	. "github.com/patrixr/auteur/core"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigFlags(t *testing.T) {
+	persistent := rootCmd.PersistentFlags()
+
+	t.Run("Commands don't shadow the configuration flags", func(t *testing.T) {
+		var visit func(cmd *cobra.Command)
+		visit = func(cmd *cobra.Command) {
+			cmd.LocalNonPersistentFlags().VisitAll(func(flag *pflag.Flag) {
+				assert.Nil(t, persistent.Lookup(flag.Name), "--%s of %s", flag.Name, cmd.CommandPath())
+				if flag.Shorthand != "" {
+					assert.Nil(t, persistent.ShorthandLookup(flag.Shorthand), "-%s of %s", flag.Shorthand, cmd.CommandPath())
+				}
+			})
+
+			for _, child := range cmd.Commands() {
+				visit(child)
+			}
+		}
+
+		visit(rootCmd)
+	})
+
+	t.Run("Aliases set the same setting", func(t *testing.T) {
+		for _, field := range ConfigFields() {
+			if alias, ok := configAliases[field.Key]; ok {
+				assert.NotNil(t, persistent.Lookup(alias), field.Key)
+				assert.Same(t, persistent.Lookup(field.Flag()).Value, persistent.Lookup(alias).Value, field.Key)
+			}
+		}
+	})
+}
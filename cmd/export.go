@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/patrixr/auteur/builder"
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+	"github.com/spf13/cobra"
+)
+
+var exportFormat string
+var exportDest string
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the page tree of the site as JSON or YAML",
+	Long: `Export the page tree of the site as JSON or YAML.
+The export contains the title, url, priority, metadata, source files
+and rendered HTML of every page, for other tools to consume.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := loadConfig()
+		if err != nil {
+			LogError(err)
+			os.Exit(1)
+		}
+
+		out, err := export(config)
+		if err != nil {
+			LogError(err)
+			os.Exit(1)
+		}
+
+		Log("Export completed successfully", "out", out)
+	},
+}
+
+// export ingests the site and writes its page tree to the export folder,
+// translated sites are exported once per locale
+func export(config AuteurConfig) (string, error) {
+	var exporter builder.Builder
+
+	switch builder.ExportFormat(exportFormat) {
+	case builder.ExportJSON:
+		exporter = builder.NewJSONBuilder()
+	case builder.ExportYAML:
+		exporter = builder.NewYAMLBuilder()
+	default:
+		return "", fmt.Errorf("Unknown export format %s, expected json or yaml", exportFormat)
+	}
+
+	out := config.Outfolder
+	if exportDest != "" {
+		abs, err := filepath.Abs(exportDest)
+		if err != nil {
+			return "", err
+		}
+		out = abs
+	}
+
+	if len(config.Locales) == 0 {
+		site, err := ingestSite(config, buildOptions{})
+		if err != nil {
+			return "", err
+		}
+		return out, exporter.Render(site, out)
+	}
+
+	for _, lang := range config.Locales {
+		site, err := ingestSite(config.ForLocale(lang), buildOptions{})
+		if err != nil {
+			return "", err
+		}
+
+		if err := exporter.Render(site, filepath.Join(out, lang)); err != nil {
+			return "", err
+		}
+	}
+
+	return out, nil
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "json", "Format of the export, json or yaml")
+	exportCmd.Flags().StringVarP(&exportDest, "dest", "d", "", "Folder the export is written to (default is the output folder)")
+}
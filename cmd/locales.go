@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/patrixr/auteur/builder"
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+)
+
+// buildLocales builds one site per locale into its own subfolder of the output folder.
+// Every locale is ingested before rendering, as pages link to their translations
+func buildLocales(config AuteurConfig, opts buildOptions) (*Auteur, error) {
+	sites := []*Auteur{}
+
+	for _, lang := range config.Locales {
+		Log("Building locale", "lang", lang)
+
+		site, err := ingestSite(config.ForLocale(lang), opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build locale %s: %w", lang, err)
+		}
+
+		sites = append(sites, site)
+	}
+
+	for _, site := range sites {
+		site.UseTranslations(sites)
+
+		if err := renderSite(site, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := builder.WriteRedirect(config.Outfolder, strings.TrimRight(sites[0].Webroot, "/")+"/"); err != nil {
+		return nil, err
+	}
+
+	if err := builder.WriteRootSiteFiles(config, sites, config.Outfolder); err != nil {
+		return nil, err
+	}
+
+	return sites[0], nil
+}
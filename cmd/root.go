@@ -4,6 +4,7 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/patrixr/auteur/builder"
@@ -20,36 +21,132 @@ var rootCmd = &cobra.Command{
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	Run: func(cmd *cobra.Command, args []string) {
-		auteur, err := NewAuteur()
+		config, err := build(buildOptions{Strict: strict})
 		if err != nil {
 			LogError(err)
 			os.Exit(1)
 		}
 
-		Log("Booting Auteur", "root", auteur.Rootdir)
+		Log("Auteur completed successfully", "out", config.Outfolder)
+	},
+}
 
-		auteur.RegisterProcessor(NewCommentReader())
-		auteur.RegisterProcessor(NewMarkdownProcessor())
+var strict bool
 
-		if err := auteur.Ingest(auteur.Rootdir); err != nil {
-			LogError(err)
-			os.Exit(1)
+type buildOptions struct {
+	// Forces incremental builds regardless of the configuration
+	Incremental bool
+	// Fails the build when broken links are found
+	Strict bool
+}
+
+// build detects the configuration and renders the site, or every version
+// of the site, into the output folder
+func build(opts buildOptions) (AuteurConfig, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return config, err
+	}
+
+	if len(config.Versions) > 0 {
+		return config, buildVersions(config, opts)
+	}
+
+	_, err = buildSite(config, opts)
+	return config, err
+}
+
+// buildSite ingests the root folder and renders the resulting site into the output folder.
+// Translated sites are built once per locale, the site of the default locale is returned
+func buildSite(config AuteurConfig, opts buildOptions) (*Auteur, error) {
+	if len(config.Locales) > 0 {
+		return buildLocales(config, opts)
+	}
+
+	auteur, err := ingestSite(config, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return auteur, renderSite(auteur, opts)
+}
+
+// newSite creates a site with the processors of the configuration
+func newSite(config AuteurConfig) (*Auteur, error) {
+	auteur := NewAuteurFromConfig(config)
+
+	Log("Booting Auteur", "root", auteur.Rootdir)
+
+	for _, name := range auteur.Processors {
+		processor, err := NewProcessor(name)
+		if err != nil {
+			return nil, err
 		}
+		auteur.RegisterProcessor(processor)
+	}
 
-		if auteur.HasContent() == false {
-			LogErrorf("No Auteur-compatible content found in folder %s", auteur.Rootdir)
-			os.Exit(1)
+	for i, source := range auteur.SourceFolders {
+		for _, name := range source.Processors {
+			processor, err := NewProcessor(name)
+			if err != nil {
+				return nil, fmt.Errorf("source %s: %w", source.Root, err)
+			}
+			auteur.RegisterSourceProcessor(i, processor)
 		}
+	}
 
-		builder := builder.NewDefaultBuilder()
+	return auteur, nil
+}
 
-		if err := builder.Render(auteur, auteur.Outfolder); err != nil {
-			LogError(err)
-			os.Exit(1)
+// ingestSite creates a site with the processors of the configuration
+// and ingests its root folder
+func ingestSite(config AuteurConfig, opts buildOptions) (*Auteur, error) {
+	auteur, err := newSite(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Incremental || auteur.Incremental {
+		auteur.UseCache(LoadBuildCache(auteur.Outfolder))
+	}
+
+	if err := auteur.Ingest(auteur.Rootdir); err != nil {
+		return nil, err
+	}
+
+	if auteur.HasContent() == false {
+		return nil, fmt.Errorf("No Auteur-compatible content found in folder %s", auteur.Rootdir)
+	}
+
+	return auteur, nil
+}
+
+// renderSite renders an ingested site into its output folder and checks its links
+func renderSite(auteur *Auteur, opts buildOptions) error {
+	if err := builder.NewDefaultBuilder().Render(auteur, auteur.Outfolder); err != nil {
+		return err
+	}
+
+	broken, err := builder.CheckLinks(auteur, auteur.Outfolder)
+	if err != nil {
+		return err
+	}
+
+	for _, link := range broken {
+		LogWarn(link.String())
+	}
+
+	if opts.Strict && len(broken) > 0 {
+		return fmt.Errorf("%d broken links found", len(broken))
+	}
+
+	if cache := auteur.Cache(); cache != nil {
+		if err := cache.Save(); err != nil {
+			return err
 		}
+	}
 
-		Log("Auteur completed successfully", "out", auteur.Outfolder)
-	},
+	return nil
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -66,7 +163,7 @@ func init() {
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 
-	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.auteur.yaml)")
+	rootCmd.Flags().BoolVar(&strict, "strict", false, "Fail the build when broken links or anchors are found")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -0,0 +1,133 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+	"github.com/patrixr/auteur/server"
+	"github.com/spf13/cobra"
+)
+
+var servePort int
+var serveHost string
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Build the site and serve it locally with live reload",
+	Long: `Build the site and serve it locally with live reload.
+The root folder is watched for changes, every change triggers a rebuild
+and open browsers are reloaded automatically.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := build(buildOptions{Incremental: true})
+		if err != nil {
+			LogError(err)
+			os.Exit(1)
+		}
+
+		srv := server.NewServer(config.Outfolder, config.Webroot)
+
+		var mutex sync.Mutex
+
+		rebuild := func() {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			Log("Change detected, rebuilding")
+
+			if _, err := build(buildOptions{Incremental: true}); err != nil {
+				LogError(err)
+				return
+			}
+
+			srv.Reload()
+		}
+
+		folders := watchedFolders(config)
+		watching := []string{}
+
+		for folder, exclusion := range folders {
+			watching = append(watching, folder)
+
+			watcher, err := server.NewWatcher(folder, exclusion, config.Outfolder)
+			if err != nil {
+				LogError(err)
+				os.Exit(1)
+			}
+			defer watcher.Close()
+
+			go watcher.Watch(rebuild)
+		}
+
+		// Theme folders living outside of the watched folders are watched separately
+		themeOutside := config.ThemeDir != ""
+		for folder := range folders {
+			if rel, err := filepath.Rel(folder, config.ThemeDir); err == nil && !strings.HasPrefix(rel, "..") {
+				themeOutside = false
+			}
+		}
+
+		if themeOutside {
+			themeWatcher, err := server.NewWatcher(config.ThemeDir, NewExclusion(config.ThemeDir, nil, nil, false), config.Outfolder)
+			if err != nil {
+				LogError(err)
+				os.Exit(1)
+			}
+			defer themeWatcher.Close()
+
+			go themeWatcher.Watch(rebuild)
+		}
+
+		addr := fmt.Sprintf("%s:%d", serveHost, servePort)
+		url := fmt.Sprintf("http://%s%s", addr, "/"+strings.TrimLeft(config.Webroot, "/"))
+
+		sort.Strings(watching)
+		Log("Serving site", "url", url, "watching", strings.Join(watching, ", "))
+
+		if err := http.ListenAndServe(addr, srv.Handler()); err != nil {
+			LogError(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().IntVarP(&servePort, "port", "p", 8080, "Port to serve the site on")
+	serveCmd.Flags().StringVar(&serveHost, "host", "localhost", "Host to serve the site on")
+}
+
+// watchedFolders returns the folders ingested into the site, either the root folder
+// or the sources of the configuration, along with the exclusion rules of each one
+func watchedFolders(config AuteurConfig) map[string]*Exclusion {
+	if len(config.SourceFolders) == 0 {
+		return map[string]*Exclusion{
+			config.Rootdir: NewExclusion(config.Rootdir, config.Exclude, config.Include, config.IgnoreFiles),
+		}
+	}
+
+	folders := map[string]*Exclusion{}
+
+	for _, source := range config.SourceFolders {
+		include := config.Include
+		if len(source.Include) > 0 {
+			include = source.Include
+		}
+
+		exclude := append(append([]string{}, config.Exclude...), source.Exclude...)
+		folders[source.Root] = NewExclusion(source.Root, exclude, include, config.IgnoreFiles)
+	}
+
+	return folders
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/patrixr/auteur/builder"
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+)
+
+// buildVersions builds every version listed in the configuration into its own
+// subfolder of the output folder, then writes the manifest used by the version picker
+func buildVersions(config AuteurConfig, opts buildOptions) error {
+	sites := []*Auteur{}
+
+	// Names are checked before anything is built, as they are used as output folders
+	for _, version := range config.Versions {
+		if err := version.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, version := range config.Versions {
+		versionConfig := config.ForVersion(version)
+
+		if version.Ref != "" {
+			checkout, rootdir, err := checkoutVersion(config.Rootdir, version)
+			if err != nil {
+				return err
+			}
+			versionConfig.Rootdir = rootdir
+
+			// Source links point at the files of the ref
+			versionConfig.Repository.Dir = checkout
+			versionConfig.Repository.Branch = version.Ref
+		}
+
+		Log("Building version", "version", version.Name, "root", versionConfig.Rootdir)
+
+		site, err := buildSite(versionConfig, opts)
+		if err != nil {
+			return fmt.Errorf("failed to build version %s: %w", version.Name, err)
+		}
+
+		sites = append(sites, site)
+	}
+
+	if err := builder.WriteVersions(sites, config.Outfolder); err != nil {
+		return err
+	}
+
+	return builder.WriteRootSiteFiles(config, sites, config.Outfolder)
+}
+
+// checkoutVersion extracts the git ref of a version and returns the checkout folder,
+// along with the folder matching the root folder inside of it. Checkouts are kept
+// in a stable location so that incremental builds can reuse their cache, and are
+// only extracted again when the ref points to a different commit
+func checkoutVersion(rootdir string, version VersionConfig) (string, string, error) {
+	if version.Root != "" {
+		rootdir = version.Root
+	}
+
+	// Git reports the toplevel folder with symlinks resolved
+	if resolved, err := filepath.EvalSymlinks(rootdir); err == nil {
+		rootdir = resolved
+	}
+
+	toplevel, err := GitToplevel(rootdir)
+	if err != nil {
+		return "", "", err
+	}
+
+	rel, err := filepath.Rel(toplevel, rootdir)
+	if err != nil {
+		return "", "", err
+	}
+
+	commit, err := GitCommit(toplevel, version.Ref)
+	if err != nil {
+		return "", "", err
+	}
+
+	hash := sha256.Sum256([]byte(toplevel + "@" + version.Ref))
+	checkout := filepath.Join(os.TempDir(), "auteur-versions", hex.EncodeToString(hash[:8]))
+
+	// The commit of the checkout is recorded next to it, to keep it out of the ingested files
+	marker := checkout + ".commit"
+
+	if previous, err := os.ReadFile(marker); err == nil && string(previous) == commit {
+		if info, err := os.Stat(checkout); err == nil && info.IsDir() {
+			Log("Reusing checkout", "version", version.Name, "commit", commit)
+			return checkout, filepath.Join(checkout, rel), nil
+		}
+	}
+
+	if err := os.Remove(marker); err != nil && !os.IsNotExist(err) {
+		return "", "", err
+	}
+
+	if err := Rmdir(checkout); err != nil {
+		return "", "", err
+	}
+
+	if err := Mkdirp(checkout); err != nil {
+		return "", "", err
+	}
+
+	if err := GitExport(toplevel, commit, checkout); err != nil {
+		return "", "", err
+	}
+
+	if err := os.WriteFile(marker, []byte(commit), 0644); err != nil {
+		return "", "", err
+	}
+
+	return checkout, filepath.Join(checkout, rel), nil
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	. "github.com/patrixr/auteur/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckoutVersion(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	t.Setenv("TMPDIR", t.TempDir())
+
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		assert.NoError(t, err, string(out))
+	}
+
+	commit := func(file string, content string) {
+		assert.NoError(t, os.MkdirAll(filepath.Join(repo, filepath.Dir(file)), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(repo, file), []byte(content), 0644))
+		git("add", "-A")
+		git("commit", "-q", "-m", file)
+	}
+
+	git("init", "-q", "-b", "main")
+	commit("docs/intro.md", "# Intro")
+
+	version := VersionConfig{Name: "stable", Ref: "main"}
+
+	checkout, rootdir, err := checkoutVersion(filepath.Join(repo, "docs"), version)
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(rootdir, "intro.md"))
+
+	t.Run("Checkouts are reused while the ref doesn't move", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(filepath.Join(checkout, "docs", "cached.txt"), nil, 0644))
+
+		again, _, err := checkoutVersion(filepath.Join(repo, "docs"), version)
+		assert.NoError(t, err)
+		assert.Equal(t, checkout, again)
+		assert.FileExists(t, filepath.Join(checkout, "docs", "cached.txt"))
+	})
+
+	t.Run("Checkouts are extracted again when the ref moves", func(t *testing.T) {
+		commit("docs/setup.md", "# Setup")
+
+		_, rootdir, err := checkoutVersion(filepath.Join(repo, "docs"), version)
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(rootdir, "setup.md"))
+		assert.NoFileExists(t, filepath.Join(rootdir, "cached.txt"))
+	})
+
+	t.Run("Unknown refs fail the checkout", func(t *testing.T) {
+		_, _, err := checkoutVersion(filepath.Join(repo, "docs"), VersionConfig{Name: "old", Ref: "v0.1.0"})
+		assert.ErrorContains(t, err, "v0.1.0")
+	})
+}
+
+func TestVersionNames(t *testing.T) {
+	outfolder := filepath.Join(t.TempDir(), "dist")
+
+	for _, name := range []string{"", ".", "..", "../site", "v1/../..", "v1/beta"} {
+		config := AuteurConfig{Outfolder: outfolder, Versions: []VersionConfig{{Name: "v2"}, {Name: name}}}
+
+		assert.Error(t, buildVersions(config, buildOptions{}), name)
+		assert.NoDirExists(t, outfolder, name)
+	}
+
+	assert.NoError(t, VersionConfig{Name: "v1.2"}.Validate())
+}
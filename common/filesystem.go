@@ -48,6 +48,11 @@ func Rmdir(path string) error {
 	return nil
 }
 
+func FileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
 func ToSlug(text string) string {
 	return textcase.KebabCase(text)
 }
@@ -0,0 +1,100 @@
+package common
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// GitToplevel returns the root folder of the git repository containing the given folder
+func GitToplevel(dir string) (string, error) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", fmt.Errorf("%s is not inside a git repository: %w", dir, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GitCommit returns the hash of the commit a git tag, branch or commit of a repository points to
+func GitCommit(repo string, ref string) (string, error) {
+	out, err := exec.Command("git", "-C", repo, "rev-parse", "--verify", ref+"^{commit}").Output()
+	if err != nil {
+		return "", fmt.Errorf("unknown git ref %s", ref)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GitExport extracts the files of a git tag, branch or commit of a repository into the dest folder
+func GitExport(repo string, ref string, dest string) error {
+	var stderr bytes.Buffer
+
+	cmd := exec.Command("git", "-C", repo, "archive", "--format=tar", ref)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	if err := untar(out, dest); err != nil {
+		cmd.Wait()
+		return err
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("failed to export %s: %s", ref, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+func untar(r io.Reader, dest string) error {
+	reader := tar.NewReader(r)
+
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dest, filepath.FromSlash(header.Name))
+		if rel, err := filepath.Rel(dest, target); err != nil || strings.HasPrefix(rel, "..") {
+			return fmt.Errorf("invalid path in archive: %s", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := Mkdirp(target); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := Mkdirp(filepath.Dir(target)); err != nil {
+				return err
+			}
+
+			file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode)&os.ModePerm)
+			if err != nil {
+				return err
+			}
+
+			_, err = io.Copy(file, reader)
+			file.Close()
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
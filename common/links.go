@@ -0,0 +1,123 @@
+package common
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// SourceLinkScheme is the scheme of the placeholder urls links to source files are
+// rewritten to. Placeholders are resolved to the url of the page the file ended up in
+// once the whole site is known
+const SourceLinkScheme = "auteur-src"
+
+var sourceFileKey = parser.NewContextKey()
+
+// SourceLinks is a goldmark extension rewriting links to local files,
+// relative to the markdown source file, into source link placeholders
+type SourceLinks struct{}
+
+func (e *SourceLinks) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithASTTransformers(
+		util.Prioritized(&sourceLinkTransformer{}, 999),
+	))
+}
+
+type sourceLinkTransformer struct{}
+
+func (t *sourceLinkTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source, _ := pc.Get(sourceFileKey).(string)
+
+	if source == "" {
+		return
+	}
+
+	ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		if link, ok := node.(*ast.Link); ok {
+			if placeholder, ok := SourceLink(source, string(link.Destination)); ok {
+				link.Destination = []byte(placeholder)
+			}
+		}
+
+		return ast.WalkContinue, nil
+	})
+}
+
+// SourceLink returns the placeholder of a link relative to the source file,
+// if it points at an existing local file or folder
+func SourceLink(source string, link string) (string, bool) {
+	parsed, err := url.Parse(link)
+	if err != nil || parsed.Scheme != "" || parsed.Host != "" || parsed.Path == "" || strings.HasPrefix(parsed.Path, "/") {
+		return "", false
+	}
+
+	target := filepath.Join(filepath.Dir(source), filepath.FromSlash(parsed.Path))
+
+	info, err := os.Stat(target)
+	if err != nil {
+		return "", false
+	}
+
+	// Folders are documented by their readme or index file
+	if info.IsDir() {
+		entries, err := os.ReadDir(target)
+		if err != nil {
+			return "", false
+		}
+
+		found := false
+		for _, entry := range entries {
+			name := strings.ToLower(entry.Name())
+			if name == "readme.md" || name == "index.md" {
+				target = filepath.Join(target, entry.Name())
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return "", false
+		}
+	}
+
+	targetPath := filepath.ToSlash(target)
+	if !strings.HasPrefix(targetPath, "/") {
+		targetPath = "/" + targetPath
+	}
+
+	placeholder := url.URL{
+		Scheme:   SourceLinkScheme,
+		Path:     targetPath,
+		RawQuery: url.Values{"link": []string{link}}.Encode(),
+		Fragment: parsed.Fragment,
+	}
+
+	return placeholder.String(), true
+}
+
+// ParseSourceLink returns the source file, fragment and original link of a placeholder
+func ParseSourceLink(placeholder string) (file string, fragment string, original string, ok bool) {
+	parsed, err := url.Parse(placeholder)
+	if err != nil || parsed.Scheme != SourceLinkScheme {
+		return "", "", "", false
+	}
+
+	file = filepath.FromSlash(parsed.Path)
+	if runtime.GOOS == "windows" {
+		file = strings.TrimPrefix(file, `\`)
+	}
+
+	return file, parsed.Fragment, parsed.Query().Get("link"), true
+}
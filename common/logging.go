@@ -33,3 +33,12 @@ func LogDebug(msg interface{}, keyvals ...interface{}) {
 func LogDebugf(format string, args ...interface{}) {
 	log.Debugf(format, args...)
 }
+
+// SetQuiet hides informational logs when enabled, warnings and errors are still logged
+func SetQuiet(quiet bool) {
+	if quiet {
+		log.SetLevel(log.WarnLevel)
+	} else {
+		log.SetLevel(log.InfoLevel)
+	}
+}
@@ -4,21 +4,37 @@ import (
 	"bytes"
 	"io"
 
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
 	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
 	"go.abhg.dev/goldmark/frontmatter"
 	"go.abhg.dev/goldmark/mermaid"
 )
 
+// VendorScheme is the scheme of the placeholder urls of third-party scripts, e.g auteur-vendor:mermaid/mermaid.min.js.
+// Placeholders are resolved to the CDN of the package, or to its vendored copy for offline builds
+const VendorScheme = "auteur-vendor"
+
 // Project-wide markdown converter
 var converter = goldmark.New(
 	goldmark.WithExtensions(
 		&frontmatter.Extender{},
 		&mermaid.Extender{
 			RenderMode: mermaid.RenderModeClient,
+			MermaidURL: VendorScheme + ":mermaid/mermaid.min.js",
 		},
 		extension.NewTable(),
+		&SourceLinks{},
+		// Code is highlighted with css classes rather than inline styles,
+		// the colors are provided by the highlight.css stylesheet of the site
+		highlighting.NewHighlighting(
+			highlighting.WithFormatOptions(chromahtml.WithClasses(true)),
+		),
+	),
+	goldmark.WithParserOptions(
+		parser.WithAutoHeadingID(),
 	),
 )
 
@@ -39,15 +55,32 @@ func MarkdownToHTMLWithMeta(md []byte) (Metadata, string, error) {
 	return meta, buf.String(), nil
 }
 
+// MarkdownFileToHTMLWithMeta converts markdown extracted from a source file.
+// Relative links to other local files are rewritten into source link placeholders
+func MarkdownFileToHTMLWithMeta(md []byte, source string) (Metadata, string, error) {
+	var buf bytes.Buffer
+
+	ctx := parser.NewContext()
+	ctx.Set(sourceFileKey, source)
+
+	meta, err := convert(md, &buf, ctx)
+	if err != nil {
+		return meta, "", err
+	}
+	return meta, buf.String(), nil
+}
+
 func ConvertMarkdown(md []byte, w io.Writer) error {
 	_, err := ConvertMarkdownWithMeta(md, w)
 	return err
 }
 
 func ConvertMarkdownWithMeta(md []byte, w io.Writer) (Metadata, error) {
-	var meta Metadata
+	return convert(md, w, parser.NewContext())
+}
 
-	ctx := parser.NewContext()
+func convert(md []byte, w io.Writer, ctx parser.Context) (Metadata, error) {
+	var meta Metadata
 
 	if err := converter.Convert(md, w, parser.WithContext(ctx)); err != nil {
 		return meta, err
@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"gopkg.in/yaml.v3"
@@ -22,3 +23,26 @@ func (m *Metadata) PrettyPrint() string {
 	}
 	return string(yamlData)
 }
+
+// Layouts accepted for dates in the metadata
+var DateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// ParseDate returns the date held by a metadata value, decoded either as a time or a string
+func ParseDate(value interface{}) (time.Time, bool) {
+	switch value := value.(type) {
+	case time.Time:
+		return value, true
+	case string:
+		for _, layout := range DateLayouts {
+			if date, err := time.Parse(layout, value); err == nil {
+				return date, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -2,7 +2,6 @@ package core
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -12,11 +11,28 @@ import (
 type Auteur struct {
 	AuteurConfig
 
-	Content    []Content
+	Content []Content
+	// Icon shown next to the page in the navigation
+	Icon string
+	// Expanded pages show their children in the navigation by default
+	Expanded bool
+
+	// Slug of the page when it no longer follows its title, e.g pages relabelled by the nav
+	slug       string
+	sources    []string
 	parent     *Auteur
 	root       *Auteur
 	children   []*Auteur
 	processors []Processor
+	cache      *BuildCache
+	// Sites of every locale, when the site is translated
+	translations []*Auteur
+	// Configuration files of the subfolders of the ingested folders
+	scopes []folderScope
+	// Folders ingested into the site, set when the files are collected
+	roots []*sourceRoot
+	// Processors of the sources of the configuration, by index
+	sourceProcessors map[int][]Processor
 }
 
 // NewAuteur creates a new site
@@ -28,16 +44,24 @@ func NewAuteur() (*Auteur, error) {
 		return nil, err
 	}
 
+	return NewAuteurFromConfig(config), nil
+}
+
+// NewAuteurFromConfig creates a new site using the given configuration
+func NewAuteurFromConfig(config AuteurConfig) *Auteur {
 	return &Auteur{
 		AuteurConfig: config,
 		parent:       nil,
 		root:         nil,
 		Content:      []Content{},
 		processors:   []Processor{},
-	}, nil
+	}
 }
 
 func (site *Auteur) Slug() string {
+	if site.slug != "" {
+		return site.slug
+	}
 	return common.ToSlug(site.Title)
 }
 
@@ -78,66 +102,97 @@ func (site *Auteur) Children() []*Auteur {
 	return site.children
 }
 
+// Sources returns the source files that contributed content to this page
+func (site *Auteur) Sources() []string {
+	return site.sources
+}
+
 // RegisterProcessor Registers a processor to be used when ingesting files
 // Each processor is responsible for transforming specific file types
 func (site *Auteur) RegisterProcessor(processor Processor) {
 	site.processors = append(site.processors, processor)
 }
 
-// Ingest Given a folder, this function ingests all files and directories within it
-// using the registered processors to transform files into site content
-func (site *Auteur) Ingest(infolder string) error {
-	files, err := os.ReadDir(infolder)
+// UseCache enables incremental builds, files that haven't changed since
+// the build the cache was saved by are not processed again
+func (site *Auteur) UseCache(cache *BuildCache) {
+	site.cache = cache
+}
 
-	if err != nil {
-		return err
-	}
+// Cache returns the build cache of the site, or nil if builds aren't incremental
+func (site *Auteur) Cache() *BuildCache {
+	return site.Root().cache
+}
 
-	for _, file := range files {
-		abspath, err := filepath.Abs(filepath.Join(infolder, file.Name()))
+// UseTranslations links the site to the sites of every locale it is translated to
+func (site *Auteur) UseTranslations(sites []*Auteur) {
+	site.translations = sites
+}
 
-		if err != nil {
-			return err
-		}
+// Translations returns the sites of every locale, including this one
+func (site *Auteur) Translations() []*Auteur {
+	return site.Root().translations
+}
 
-		if IsExcluded(file.Name(), site.Exclude) {
-			common.Log("Excluding " + abspath)
-			continue
-		}
+// FindPage returns the page with the given href, or nil if there is none
+func (site *Auteur) FindPage(href string) *Auteur {
+	if site.Href() == href {
+		return site
+	}
 
-		// Recurse into directories
-		if file.IsDir() {
-			if err := site.Ingest(abspath); err != nil {
-				return err
-			}
-			continue
+	for _, child := range site.children {
+		if page := child.FindPage(href); page != nil {
+			return page
 		}
+	}
 
-		ext := filepath.Ext(file.Name())
+	return nil
+}
 
-		for _, processor := range site.processors {
-			if !processor.Supports(ext) {
-				continue
-			}
+// Ingest Given a folder, this function ingests all files and directories within it
+// using the registered processors to transform files into site content.
+// Files are loaded concurrently, but their content is merged into the page tree
+// in traversal order so that the result is identical to a serial run
+func (site *Auteur) Ingest(infolder string) error {
+	if site.cache != nil {
+		site.cache.begin(site)
+	}
 
-			contents, err := processor.Load(site, abspath)
+	files, err := site.collectFiles(infolder)
+	if err != nil {
+		return err
+	}
 
-			if err != nil {
-				return err
-			}
+	loaded, err := site.loadFiles(files)
+	if err != nil {
+		return err
+	}
 
-			for _, content := range contents {
-				site.AddContent(content)
-			}
-		}
+	fallbacks := make([][]Content, len(files))
+	for i, file := range files {
+		fallbacks[i] = site.addFileContents(file, loaded[i])
+	}
+
+	site.addFallbacks(files, fallbacks)
+	site.applyScopes()
+
+	if len(site.Nav) > 0 {
+		site.ApplyNav(site.Nav)
+	}
+
+	if site.cache != nil {
+		site.recordContributions(site.cache)
+		site.cache.end()
 	}
+
 	return nil
 }
 
 // AddContent Adds HTML/Markdown content to the site
-func (site *Auteur) AddContent(content Content) {
+// Returns the page the content was added to, or nil if the content was empty
+func (site *Auteur) AddContent(content Content) *Auteur {
 	if content == nil || content.Len() == 0 {
-		return
+		return nil
 	}
 
 	ref := site
@@ -174,6 +229,7 @@ func (site *Auteur) AddContent(content Content) {
 	}
 
 	ref.Content = ordered
+	return ref
 }
 
 // GetSubpage retrieves a subpage with the given title. If the subpage does not exist, it creates a new one.
@@ -261,23 +317,6 @@ func (site *Auteur) HasContent() bool {
 	return false
 }
 
-func IsExcluded(filename string, patterns []string) bool {
-	for _, pattern := range patterns {
-
-		if pattern == filename {
-			return true
-		}
-
-		pattern = filepath.FromSlash(pattern)
-
-		matched, err := filepath.Match(pattern, filename)
-		if err == nil && matched {
-			return true
-		}
-	}
-	return false
-}
-
 func (site *Auteur) GetRelativePath(path string) (string, error) {
 	cwd, err := filepath.Abs(site.Rootdir)
 
@@ -1,8 +1,13 @@
 package core
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/charmbracelet/log"
 	. "github.com/patrixr/auteur/common"
 	"github.com/stretchr/testify/assert"
 )
@@ -19,6 +24,8 @@ func (m MockContent) Meta() Metadata    { return Metadata{} }
 func (m MockContent) Title() string     { return "" }
 func (m MockContent) Type() ContentType { return Markdown }
 func (m MockContent) Priority() int     { return 0 }
+func (m MockContent) Locale() string    { return "" }
+func (m MockContent) Origin() Origin    { return Origin{} }
 
 type MockProcessor struct {
 	supportedExt string
@@ -156,3 +163,267 @@ func TestSite(t *testing.T) {
 		assert.Len(t, site.processors, 1)
 	})
 }
+
+func TestParallelIngest(t *testing.T) {
+	rootdir := t.TempDir()
+
+	for _, folder := range []string{"a", "b", "a/c"} {
+		os.MkdirAll(filepath.Join(rootdir, folder), 0755)
+	}
+
+	files := []string{"one.txt", "a/two.txt", "a/c/three.txt", "b/four.txt", "b/five.txt", "six.txt"}
+	for _, file := range files {
+		os.WriteFile(filepath.Join(rootdir, file), []byte(file), 0644)
+	}
+
+	ingest := func(workers int) *Auteur {
+		site, err := NewAuteur()
+		assert.NoError(t, err)
+		site.Workers = workers
+		site.RegisterProcessor(&CountingProcessor{loads: map[string]int{}})
+		site.RegisterProcessor(&CountingProcessor{loads: map[string]int{}})
+		assert.NoError(t, site.Ingest(rootdir))
+		return site
+	}
+
+	signature := func(site *Auteur) string {
+		var sb strings.Builder
+		var traverse func(page *Auteur)
+		traverse = func(page *Auteur) {
+			sb.WriteString(page.Href() + "\n")
+			for _, content := range page.Content {
+				sb.WriteString(content.Data() + "\n")
+			}
+			for _, child := range page.Children() {
+				traverse(child)
+			}
+		}
+		traverse(site)
+		return sb.String()
+	}
+
+	serial := signature(ingest(1))
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, serial, signature(ingest(8)))
+	}
+}
+
+func TestLocales(t *testing.T) {
+	rootdir := t.TempDir()
+	os.WriteFile(filepath.Join(rootdir, "docs.txt"), []byte("docs"), 0644)
+
+	processor := MockProcessor{
+		supportedExt: ".txt",
+		contents: []Content{
+			&CachedContent{Kind: HTML, Body: "setup", Segments: []string{"setup"}},
+			&CachedContent{Kind: HTML, Body: "installation", Segments: []string{"setup"}, Language: "fr"},
+			&CachedContent{Kind: HTML, Body: "about", Segments: []string{"about"}},
+			&CachedContent{Kind: HTML, Body: "anleitung", Segments: []string{"guide"}, Language: "de"},
+		},
+	}
+
+	ingest := func(lang string) *Auteur {
+		config, err := DetectConfig()
+		assert.NoError(t, err)
+		config.Locales = []string{"en", "fr", "de"}
+
+		site := NewAuteurFromConfig(config.ForLocale(lang))
+		site.RegisterProcessor(processor)
+		assert.NoError(t, site.Ingest(rootdir))
+		return site
+	}
+
+	t.Run("Default locale only contains untagged content", func(t *testing.T) {
+		site := ingest("en")
+		assert.Equal(t, "setup", site.FindPage("/setup").Content[0].Data())
+		assert.Equal(t, "about", site.FindPage("/about").Content[0].Data())
+		assert.Nil(t, site.FindPage("/guide"))
+	})
+
+	t.Run("Untranslated pages fall back to the default locale", func(t *testing.T) {
+		site := ingest("fr")
+		assert.Len(t, site.FindPage("/setup").Content, 1)
+		assert.Equal(t, "installation", site.FindPage("/setup").Content[0].Data())
+		assert.Equal(t, "about", site.FindPage("/about").Content[0].Data())
+		assert.Nil(t, site.FindPage("/guide"))
+	})
+
+	t.Run("Locales are rendered under their own webroot", func(t *testing.T) {
+		site := ingest("de")
+		assert.Equal(t, "/de", site.Webroot)
+		assert.Equal(t, "de", site.Lang)
+		assert.NotNil(t, site.FindPage("/guide"))
+	})
+}
+
+func TestNav(t *testing.T) {
+	collapsed := false
+
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+
+	site.AddContent(MockContent{path: []string{"guides", "setup"}, len: 1})
+	site.AddContent(MockContent{path: []string{"guides", "deploy"}, len: 1})
+	site.AddContent(MockContent{path: []string{"api"}, len: 1})
+	site.AddContent(MockContent{path: []string{"api", "users"}, len: 1})
+	site.AddContent(MockContent{path: []string{"drafts"}, len: 1})
+
+	site.ApplyNav([]NavItem{
+		{Page: "/api", Title: "Reference", Icon: "book"},
+		{Title: "Start here", Collapsed: &collapsed, Children: []NavItem{
+			{Page: "/guides/setup/"},
+			{Page: "guides/deploy", Title: "Deploying"},
+		}},
+		{Page: "/missing"},
+	})
+
+	titles := func(pages []*Auteur) []string {
+		result := []string{}
+		for _, page := range pages {
+			result = append(result, page.Title)
+		}
+		return result
+	}
+
+	t.Run("Top level sections follow the nav order", func(t *testing.T) {
+		assert.Equal(t, []string{"Reference", "Start here"}, titles(site.Children()))
+	})
+
+	t.Run("Listed pages keep their inferred children", func(t *testing.T) {
+		reference := site.Children()[0]
+		assert.Equal(t, "book", reference.Icon)
+		assert.Equal(t, "/api/users", reference.Children()[0].Href())
+	})
+
+	t.Run("Sections group explicitly listed pages", func(t *testing.T) {
+		section := site.Children()[1]
+		assert.True(t, section.Expanded)
+		assert.Empty(t, section.Content)
+		assert.Equal(t, []string{"setup", "Deploying"}, titles(section.Children()))
+		assert.Equal(t, "/start-here/deploy", section.Children()[1].Href())
+	})
+
+	t.Run("Titles don't change the url of listed pages", func(t *testing.T) {
+		assert.Equal(t, "Reference", site.FindPage("/api").Title)
+		assert.Nil(t, site.FindPage("/reference"))
+	})
+
+	t.Run("Unlisted pages are dropped", func(t *testing.T) {
+		assert.Nil(t, site.FindPage("/drafts"))
+		assert.Nil(t, site.FindPage("/guides"))
+	})
+}
+
+func TestNavWarnings(t *testing.T) {
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+
+	site.AddContent(MockContent{path: []string{"guides", "setup"}, len: 1})
+	site.AddContent(MockContent{path: []string{"api"}, len: 1})
+	site.AddContent(MockContent{path: []string{"api", "users"}, len: 1})
+
+	site.ApplyNav([]NavItem{
+		{Page: "/api"},
+		{Title: "Start here", Children: []NavItem{
+			{Page: "/guides/setup"},
+			{Title: "Setup"},
+		}},
+	})
+
+	t.Run("Moved pages are reported with their new url", func(t *testing.T) {
+		assert.Contains(t, output.String(), "from=/guides/setup to=/start-here/setup")
+		assert.NotContains(t, output.String(), "from=/api")
+	})
+
+	t.Run("Siblings sharing a url are reported", func(t *testing.T) {
+		assert.Contains(t, output.String(), "Nav items share the same url href=/start-here/setup")
+		assert.Equal(t, 1, strings.Count(output.String(), "share the same url"))
+	})
+}
+
+type LintingMockProcessor struct {
+	MockProcessor
+	problems []Problem
+}
+
+func (m LintingMockProcessor) Lint(_ *Auteur, file string) []Problem {
+	if filepath.Base(file) != "broken.txt" {
+		return nil
+	}
+	return m.problems
+}
+
+func TestCheck(t *testing.T) {
+	rootdir := t.TempDir()
+
+	for _, file := range []string{"broken.txt", "setup.txt"} {
+		os.WriteFile(filepath.Join(rootdir, file), []byte(file), 0644)
+	}
+
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+	site.Rootdir = rootdir
+	site.Exclude = append(site.Exclude, "drafts")
+
+	site.RegisterProcessor(LintingMockProcessor{
+		MockProcessor: MockProcessor{
+			supportedExt: ".txt",
+			contents: []Content{
+				&CachedContent{Kind: HTML, Body: "setup", Heading: "Setup", Segments: []string{"setup"}, Source: Origin{File: filepath.Join(rootdir, "setup.txt")}},
+				&CachedContent{Kind: HTML, Body: " ", Segments: []string{"blank"}},
+			},
+		},
+		problems: []Problem{{Severity: SeverityError, Rule: "frontmatter", File: filepath.Join(rootdir, "broken.txt"), Line: 2}},
+	})
+
+	problems, err := site.Check(rootdir)
+	assert.NoError(t, err)
+	assert.True(t, HasErrors(problems))
+
+	rules := func(rule string) []Problem {
+		found := []Problem{}
+		for _, problem := range problems {
+			if problem.Rule == rule {
+				found = append(found, problem)
+			}
+		}
+		return found
+	}
+
+	t.Run("Problems of the processors are reported relative to the root folder", func(t *testing.T) {
+		assert.Equal(t, []Problem{{Severity: SeverityError, Rule: "frontmatter", File: "broken.txt", Line: 2}}, rules("frontmatter"))
+	})
+
+	t.Run("Files with errors are left out of the site", func(t *testing.T) {
+		assert.Equal(t, []string{filepath.Join(rootdir, "setup.txt")}, site.FindPage("/setup").Sources())
+		assert.Empty(t, rules("duplicate-page"))
+	})
+
+	t.Run("Blank content is reported", func(t *testing.T) {
+		assert.Len(t, rules("empty-page"), 1)
+	})
+
+	t.Run("Exclude patterns that match nothing are reported", func(t *testing.T) {
+		assert.Len(t, rules("exclude"), 1)
+		assert.Contains(t, rules("exclude")[0].Message, "drafts")
+	})
+}
+
+func TestCheckDuplicates(t *testing.T) {
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+
+	site.AddContent(&CachedContent{Kind: HTML, Body: "a", Heading: "Setup", Segments: []string{"setup"}, Source: Origin{File: "/docs/setup.md"}})
+	site.AddContent(&CachedContent{Kind: HTML, Body: "b", Segments: []string{"setup"}, Source: Origin{File: "/src/main.go", StartLine: 4}})
+	site.AddContent(&CachedContent{Kind: HTML, Body: "c", Heading: "Install", Segments: []string{"Setup"}, Source: Origin{File: "/docs/Setup/README.md", StartLine: 1}})
+
+	problems := site.checkPages()
+	assert.Len(t, problems, 1)
+	assert.Equal(t, "duplicate-page", problems[0].Rule)
+	assert.Contains(t, problems[0].Message, "Page /setup is already defined by")
+}
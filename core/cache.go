@@ -0,0 +1,306 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	. "github.com/patrixr/auteur/common"
+)
+
+const CacheFile = ".auteur-cache.json"
+
+// Bumped whenever the layout of the cache file, or the html stored in it, changes
+const cacheVersion = "6"
+
+// BuildCache records, for every ingested source file, the hash of its content,
+// the content its processors produced and the pages it contributed to.
+// It allows subsequent builds to skip unchanged files and pages
+type BuildCache struct {
+	Version     string                 `json:"version"`
+	Fingerprint string                 `json:"fingerprint"`
+	Tree        string                 `json:"tree"`
+	Files       map[string]*CachedFile `json:"files"`
+
+	path     string
+	mutex    sync.Mutex
+	previous map[string]*CachedFile
+	affected map[string]bool
+	rebuild  bool
+}
+
+// CachedFile is the cache entry of a single source file
+type CachedFile struct {
+	Hash     string          `json:"hash"`
+	Contents []CachedContent `json:"contents"`
+	Pages    []string        `json:"pages"`
+
+	changed bool
+}
+
+// CachedContent is a serializable implementation of the Content interface
+type CachedContent struct {
+	Kind     ContentType `json:"type"`
+	Body     string      `json:"data"`
+	Segments []string    `json:"path"`
+	Heading  string      `json:"title"`
+	Metadata Metadata    `json:"meta"`
+	Weight   int         `json:"priority"`
+	Language string      `json:"locale"`
+	Source   Origin      `json:"origin"`
+}
+
+func (c *CachedContent) Type() ContentType { return c.Kind }
+func (c *CachedContent) Data() string      { return c.Body }
+func (c *CachedContent) Path() []string    { return c.Segments }
+func (c *CachedContent) Title() string     { return c.Heading }
+func (c *CachedContent) Meta() Metadata    { return c.Metadata }
+func (c *CachedContent) Priority() int     { return c.Weight }
+func (c *CachedContent) Locale() string    { return c.Language }
+func (c *CachedContent) Origin() Origin    { return c.Source }
+func (c *CachedContent) Len() int          { return len(c.Body) }
+
+// LoadBuildCache reads the cache stored in the output folder.
+// A missing or unreadable cache results in an empty one, forcing a full build
+func LoadBuildCache(outfolder string) *BuildCache {
+	cache := &BuildCache{
+		Version: cacheVersion,
+		Files:   map[string]*CachedFile{},
+		path:    filepath.Join(outfolder, CacheFile),
+		rebuild: true,
+	}
+
+	data, err := os.ReadFile(cache.path)
+	if err != nil {
+		return cache
+	}
+
+	var stored BuildCache
+	if err := json.Unmarshal(data, &stored); err != nil {
+		LogWarn("Ignoring corrupted build cache", "file", cache.path, "err", err)
+		return cache
+	}
+
+	if stored.Version != cacheVersion || stored.Files == nil {
+		return cache
+	}
+
+	cache.Fingerprint = stored.Fingerprint
+	cache.Tree = stored.Tree
+	cache.Files = stored.Files
+	return cache
+}
+
+// Save writes the cache to the output folder
+func (cache *BuildCache) Save() error {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+
+	if err := Mkdirp(filepath.Dir(cache.path)); err != nil {
+		return err
+	}
+
+	return os.WriteFile(cache.path, data, 0644)
+}
+
+// Lookup returns the cached content of a file if its hash hasn't changed since the last build
+func (cache *BuildCache) Lookup(file string, hash string) ([]Content, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entry, ok := cache.previous[file]
+	if !ok || entry.Hash != hash {
+		return nil, false
+	}
+
+	cache.Files[file] = &CachedFile{Hash: hash, Contents: entry.Contents}
+
+	contents := make([]Content, len(entry.Contents))
+	for i := range entry.Contents {
+		contents[i] = &entry.Contents[i]
+	}
+
+	return contents, true
+}
+
+// Store records the content freshly produced for a file
+func (cache *BuildCache) Store(file string, hash string, contents []Content) {
+	entry := &CachedFile{
+		Hash:     hash,
+		Contents: make([]CachedContent, 0, len(contents)),
+		changed:  true,
+	}
+
+	for _, content := range contents {
+		if content == nil {
+			continue
+		}
+
+		entry.Contents = append(entry.Contents, CachedContent{
+			Kind:     content.Type(),
+			Body:     content.Data(),
+			Segments: content.Path(),
+			Heading:  content.Title(),
+			Metadata: content.Meta(),
+			Weight:   content.Priority(),
+			Language: content.Locale(),
+			Source:   content.Origin(),
+		})
+	}
+
+	cache.mutex.Lock()
+	cache.Files[file] = entry
+	cache.mutex.Unlock()
+}
+
+// Contributed records that a file contributed content to the page with the given href
+func (cache *BuildCache) Contributed(file string, href string) {
+	entry, ok := cache.Files[file]
+	if !ok {
+		return
+	}
+
+	for _, page := range entry.Pages {
+		if page == href {
+			return
+		}
+	}
+
+	entry.Pages = append(entry.Pages, href)
+}
+
+// Prepare validates the cache against the site, returning true when the
+// existing output can be reused and only affected pages need to be rendered.
+// The salt allows builders to invalidate the cache when their own templates change
+func (cache *BuildCache) Prepare(site *Auteur, salt string) bool {
+	tree := hashString(salt + treeSignature(site))
+
+	cache.rebuild = cache.rebuild || cache.Tree != tree
+	cache.Tree = tree
+
+	return !cache.rebuild
+}
+
+// Changed returns true if the page with the given href needs to be rendered again
+func (cache *BuildCache) Changed(href string) bool {
+	return cache.rebuild || cache.affected[href]
+}
+
+// begin is called before ingestion, the cache is discarded if the configuration
+// or the registered processors differ from the previous build
+func (cache *BuildCache) begin(site *Auteur) {
+	fingerprint := hashString(configSignature(site))
+
+	cache.previous = cache.Files
+	cache.Files = map[string]*CachedFile{}
+	cache.affected = map[string]bool{}
+
+	if cache.Fingerprint != fingerprint {
+		cache.previous = map[string]*CachedFile{}
+		cache.rebuild = true
+	} else {
+		cache.rebuild = false
+	}
+
+	cache.Fingerprint = fingerprint
+}
+
+// end is called after ingestion, it marks the pages of every changed,
+// added or removed file as affected
+func (cache *BuildCache) end() {
+	for file, entry := range cache.Files {
+		if !entry.changed {
+			continue
+		}
+
+		for _, href := range entry.Pages {
+			cache.affected[href] = true
+		}
+
+		if old, ok := cache.previous[file]; ok {
+			for _, href := range old.Pages {
+				cache.affected[href] = true
+			}
+		}
+	}
+
+	for file, old := range cache.previous {
+		if _, ok := cache.Files[file]; ok {
+			continue
+		}
+
+		for _, href := range old.Pages {
+			cache.affected[href] = true
+		}
+	}
+
+	cache.previous = nil
+}
+
+// HashFile returns the hex encoded sha256 of a file's content
+// Additional files can be provided, their content is then included in the hash
+func HashFile(file string, others ...string) (string, error) {
+	hash := sha256.New()
+
+	for _, f := range append([]string{file}, others...) {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return "", err
+		}
+
+		hash.Write([]byte(f))
+		hash.Write(data)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func hashString(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+func configSignature(site *Auteur) string {
+	config, _ := json.Marshal(site.AuteurConfig)
+	// Fields set while building aren't part of the configuration file format
+	config = fmt.Appendf(config, "%s,%s,", site.VersionsWebroot, site.Lang)
+
+	processors := make([]string, len(site.processors))
+	for i, processor := range site.processors {
+		processors[i] = fmt.Sprintf("%T", processor)
+	}
+
+	for source, registered := range site.sourceProcessors {
+		for _, processor := range registered {
+			processors = append(processors, fmt.Sprintf("%d:%T", source, processor))
+		}
+	}
+	sort.Strings(processors)
+
+	return string(config) + strings.Join(processors, ",")
+}
+
+// treeSignature describes the shape of the page tree, any change to it
+// affects the navigation of every page
+func treeSignature(site *Auteur) string {
+	var sb strings.Builder
+
+	var traverse func(page *Auteur)
+	traverse = func(page *Auteur) {
+		sb.WriteString(fmt.Sprintf("%s|%s|%t|%d|%s|%v\n", page.Href(), page.Title, page.HasContent(), len(page.Content), page.Theme, page.Links))
+		for _, child := range page.children {
+			traverse(child)
+		}
+	}
+
+	traverse(site)
+	return sb.String()
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type CountingProcessor struct {
+	mutex sync.Mutex
+	loads map[string]int
+}
+
+func (p *CountingProcessor) Supports(ext string) bool {
+	return ext == ".txt"
+}
+
+func (p *CountingProcessor) Load(site *Auteur, file string) ([]Content, error) {
+	p.mutex.Lock()
+	p.loads[filepath.Base(file)] += 1
+	p.mutex.Unlock()
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return []Content{&CachedContent{
+		Kind:     HTML,
+		Body:     string(data),
+		Segments: []string{filepath.Base(file)},
+	}}, nil
+}
+
+func TestBuildCache(t *testing.T) {
+	rootdir := t.TempDir()
+	outfolder := t.TempDir()
+
+	os.WriteFile(filepath.Join(rootdir, "one.txt"), []byte("one"), 0644)
+	os.WriteFile(filepath.Join(rootdir, "two.txt"), []byte("two"), 0644)
+
+	processor := &CountingProcessor{loads: map[string]int{}}
+
+	ingest := func() *Auteur {
+		site, err := NewAuteur()
+		assert.NoError(t, err)
+		site.RegisterProcessor(processor)
+		site.UseCache(LoadBuildCache(outfolder))
+		assert.NoError(t, site.Ingest(rootdir))
+		return site
+	}
+
+	t.Run("First build processes every file", func(t *testing.T) {
+		site := ingest()
+		assert.False(t, site.Cache().Prepare(site, ""))
+		assert.True(t, site.Cache().Changed("/one-txt"))
+		assert.NoError(t, site.Cache().Save())
+		assert.Equal(t, 1, processor.loads["one.txt"])
+		assert.Equal(t, 1, processor.loads["two.txt"])
+	})
+
+	t.Run("Unchanged files are served from the cache", func(t *testing.T) {
+		site := ingest()
+		assert.True(t, site.Cache().Prepare(site, ""))
+		assert.False(t, site.Cache().Changed("/one-txt"))
+		assert.False(t, site.Cache().Changed("/two-txt"))
+		assert.NoError(t, site.Cache().Save())
+		assert.Equal(t, 1, processor.loads["one.txt"])
+		assert.Equal(t, "one", site.GetSubpage("one.txt", 0).Content[0].Data())
+	})
+
+	t.Run("Only pages of changed files are affected", func(t *testing.T) {
+		os.WriteFile(filepath.Join(rootdir, "one.txt"), []byte("updated"), 0644)
+
+		site := ingest()
+		assert.True(t, site.Cache().Prepare(site, ""))
+		assert.True(t, site.Cache().Changed("/one-txt"))
+		assert.False(t, site.Cache().Changed("/two-txt"))
+		assert.Equal(t, 2, processor.loads["one.txt"])
+		assert.Equal(t, 1, processor.loads["two.txt"])
+	})
+
+	t.Run("A different tree forces a full rebuild", func(t *testing.T) {
+		site := ingest()
+		assert.False(t, site.Cache().Prepare(site, "new templates"))
+		assert.True(t, site.Cache().Changed("/two-txt"))
+	})
+}
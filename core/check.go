@@ -0,0 +1,293 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Problem is an issue found in the sources of the site by Check.
+// File is relative to the root folder, Line is 0 when the problem isn't tied to a line
+type Problem struct {
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Message  string   `json:"message"`
+}
+
+func (p Problem) String() string {
+	location := p.File
+	if location == "" {
+		location = "."
+	}
+	if p.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, p.Line)
+	}
+	return fmt.Sprintf("%s: %s: %s (%s)", location, p.Severity, p.Message, p.Rule)
+}
+
+// LintingProcessor can be implemented by processors able to report the problems
+// of a file that Load would fail on or silently ignore
+type LintingProcessor interface {
+	Lint(site *Auteur, file string) []Problem
+}
+
+// HasErrors returns true if one of the problems is an error
+func HasErrors(problems []Problem) bool {
+	for _, problem := range problems {
+		if problem.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Check ingests the folder like Ingest does and reports the problems found along the way,
+// instead of stopping at the first one. Files with errors are left out of the page tree
+func (site *Auteur) Check(infolder string) ([]Problem, error) {
+	files, err := site.collectFiles(infolder)
+	if err != nil {
+		return nil, err
+	}
+
+	problems, err := site.checkExcludes()
+	if err != nil {
+		return nil, err
+	}
+
+	valid := []string{}
+
+	for _, file := range files {
+		linted := site.lint(file)
+		problems = append(problems, linted...)
+
+		if !HasErrors(linted) {
+			valid = append(valid, file)
+		}
+	}
+
+	loaded, errs := site.loadEach(valid)
+
+	for i, file := range valid {
+		if errs[i] != nil {
+			problems = append(problems, Problem{
+				Severity: SeverityError,
+				Rule:     "load",
+				File:     file,
+				Message:  errs[i].Error(),
+			})
+			loaded[i] = nil
+			continue
+		}
+
+		for _, content := range loaded[i] {
+			if strings.TrimSpace(content.Data()) != "" {
+				continue
+			}
+
+			problems = append(problems, Problem{
+				Severity: SeverityWarning,
+				Rule:     "empty-page",
+				File:     file,
+				Line:     content.Origin().StartLine,
+				Message:  "Content is empty and won't be added to the site",
+			})
+		}
+	}
+
+	fallbacks := make([][]Content, len(valid))
+	for i, file := range valid {
+		fallbacks[i] = site.addFileContents(file, loaded[i])
+	}
+
+	site.addFallbacks(valid, fallbacks)
+	site.applyScopes()
+
+	if len(site.Nav) > 0 {
+		site.ApplyNav(site.Nav)
+	}
+
+	problems = append(problems, site.checkPages()...)
+
+	for i, problem := range problems {
+		if problem.File == "" {
+			continue
+		}
+		if rel, err := site.GetRelativePath(problem.File); err == nil && rel != "" {
+			problems[i].File = filepath.ToSlash(rel)
+		}
+	}
+
+	return problems, nil
+}
+
+// lint runs the linting processors supporting the file
+func (site *Auteur) lint(file string) []Problem {
+	ext := filepath.Ext(file)
+	problems := []Problem{}
+
+	for _, processor := range site.processorsOf(file) {
+		if linter, ok := processor.(LintingProcessor); ok && processor.Supports(ext) {
+			problems = append(problems, linter.Lint(site, file)...)
+		}
+	}
+
+	return problems
+}
+
+// checkPages reports the pages defined by more than one file, and the pages without content
+func (site *Auteur) checkPages() []Problem {
+	problems := []Problem{}
+
+	if !site.IsRoot() && len(site.Content) == 0 && len(site.children) == 0 {
+		problems = append(problems, Problem{
+			Severity: SeverityWarning,
+			Rule:     "empty-page",
+			Message:  fmt.Sprintf("Page %s has no content", site.Href()),
+		})
+	}
+
+	// Titled content defines a page, untitled content is merged into it
+	definedBy := ""
+
+	for _, content := range site.Content {
+		origin := content.Origin()
+
+		if content.Title() == "" || origin.File == "" || origin.File == definedBy {
+			continue
+		}
+
+		if definedBy == "" {
+			definedBy = origin.File
+			continue
+		}
+
+		defined := definedBy
+		if rel, err := site.GetRelativePath(definedBy); err == nil {
+			defined = filepath.ToSlash(rel)
+		}
+
+		problems = append(problems, Problem{
+			Severity: SeverityError,
+			Rule:     "duplicate-page",
+			File:     origin.File,
+			Line:     origin.StartLine,
+			Message:  fmt.Sprintf("Page %s is already defined by %s", site.Href(), defined),
+		})
+	}
+
+	for _, child := range site.children {
+		problems = append(problems, child.checkPages()...)
+	}
+
+	return problems
+}
+
+// checkExcludes reports the exclude patterns that don't match any file of the folders being ingested,
+// either because they are misspelled or because they only match files of excluded folders
+func (site *Auteur) checkExcludes() ([]Problem, error) {
+	problems := []Problem{}
+	matched := make([]bool, len(site.Exclude))
+
+	unmatched := func(pattern string, anchored bool, relativeTo string) Problem {
+		message := fmt.Sprintf("Exclude pattern %q doesn't match any file", pattern)
+		if anchored {
+			message += ", patterns containing a slash are relative to the " + relativeTo
+		}
+
+		return Problem{
+			Severity: SeverityWarning,
+			Rule:     "exclude",
+			Message:  message,
+		}
+	}
+
+	for _, root := range site.roots {
+		// Patterns of the site apply to every source, followed by the ones of the source
+		patterns := append(slices.Clip(site.Exclude), root.exclude...)
+
+		found, rules, err := root.matchExcludes(patterns)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range site.Exclude {
+			matched[i] = matched[i] || found[i]
+		}
+
+		for i, pattern := range root.exclude {
+			j := len(site.Exclude) + i
+			if len(rules[j]) > 0 && !found[j] {
+				problems = append(problems, unmatched(pattern, rules[j][0].Anchored, "source folder"))
+			}
+		}
+	}
+
+	for i, pattern := range site.Exclude {
+		rules := ParseExcludeRules([]string{pattern}, "")
+
+		// Defaults apply to any project, they aren't expected to match
+		if len(rules) == 0 || matched[i] || slices.Contains(DefaultExclude, pattern) {
+			continue
+		}
+
+		problems = append(problems, unmatched(pattern, rules[0].Anchored, "root folder"))
+	}
+
+	return problems, nil
+}
+
+// matchExcludes walks the source folder and returns, for each pattern, whether it matches
+// any of its files and folders, along with the parsed rule of each pattern
+func (root *sourceRoot) matchExcludes(patterns []string) ([]bool, [][]ExcludeRule, error) {
+	rules := make([][]ExcludeRule, len(patterns))
+	for i, pattern := range patterns {
+		rules[i] = ParseExcludeRules([]string{pattern}, root.root)
+	}
+
+	matched := make([]bool, len(patterns))
+
+	var walk func(folder string) error
+	walk = func(folder string) error {
+		entries, err := os.ReadDir(folder)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			path := filepath.Join(folder, entry.Name())
+
+			for i := range rules {
+				if len(rules[i]) > 0 && rules[i][0].Matches(path, entry.IsDir()) {
+					matched[i] = true
+				}
+			}
+
+			if !entry.IsDir() || root.exclusion.Excluded(path, true) {
+				continue
+			}
+
+			if err := walk(path); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := walk(root.root); err != nil {
+		return nil, nil, err
+	}
+
+	return matched, rules, nil
+}
@@ -1,31 +1,180 @@
 package core
 
 import (
-	"os"
+	"fmt"
+	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	. "github.com/patrixr/auteur/common"
-	"github.com/patrixr/q"
-	"gopkg.in/yaml.v3"
 )
 
 type Link struct {
-	Title string `yaml:"title"`
-	Href  string `yaml:"url"`
-	Icon  string `yaml:"icon"`
+	Title string `yaml:"title" json:"title" toml:"title"`
+	Href  string `yaml:"url" json:"url" toml:"url"`
+	Icon  string `yaml:"icon" json:"icon" toml:"icon"`
+}
+
+type OpenAPIConfig struct {
+	// Section of the site the API references are placed under
+	Path string `yaml:"path" json:"path" toml:"path"`
+}
+
+type HighlightConfig struct {
+	// Chroma style used to highlight code in light mode
+	Light string `yaml:"light" json:"light" toml:"light"`
+	// Chroma style used to highlight code in dark mode
+	Dark string `yaml:"dark" json:"dark" toml:"dark"`
+}
+
+type RobotsConfig struct {
+	// Paths crawlers are asked not to visit, relative to the webroot
+	Disallow []string `yaml:"disallow" json:"disallow" toml:"disallow"`
+}
+
+type VersionConfig struct {
+	// Name of the version, also used as the name of its output folder
+	Name string `yaml:"name" json:"name" toml:"name"`
+	// Folder containing the sources of the version, defaults to the root folder
+	Root string `yaml:"root" json:"root" toml:"root"`
+	// Git tag or branch the sources of the version are read from
+	Ref string `yaml:"ref" json:"ref" toml:"ref"`
+}
+
+// Validate returns an error if the name of the version can't be used as the name of a folder
+// of the output folder, e.g names pointing outside of it
+func (version VersionConfig) Validate() error {
+	if version.Name == "" {
+		return fmt.Errorf("versions must have a name")
+	}
+
+	if filepath.Base(version.Name) != version.Name || version.Name == "." || version.Name == ".." {
+		return fmt.Errorf("invalid version name %q, names can't contain path separators or be . or ..", version.Name)
+	}
+
+	return nil
+}
+
+type SourceConfig struct {
+	// Folder containing the sources
+	Root string `yaml:"root" json:"root" toml:"root"`
+	// Section of the site the pages of the folder are placed under, e.g services/api
+	Mount string `yaml:"mount" json:"mount" toml:"mount"`
+	// Patterns excluded from the folder, in addition to the exclude setting of the site
+	Exclude []string `yaml:"exclude" json:"exclude" toml:"exclude"`
+	// Patterns of the files of the folder to ingest, replacing the include setting of the site
+	Include []string `yaml:"include" json:"include" toml:"include"`
+	// Names of the processors used to ingest the folder, replacing the processors of the site
+	Processors []string `yaml:"processors" json:"processors" toml:"processors"`
+}
+
+type NavItem struct {
+	// Href of an ingested page, e.g /guides/setup. Items without a page are sections grouping their children
+	Page      string `yaml:"page" json:"page" toml:"page"`
+	Title     string `yaml:"title" json:"title" toml:"title"`
+	Icon      string `yaml:"icon" json:"icon" toml:"icon"`
+	Collapsed *bool  `yaml:"collapsed" json:"collapsed" toml:"collapsed"`
+	// Pages listed under the item, in order. When omitted, the inferred children of the page are kept
+	Children []NavItem `yaml:"children" json:"children" toml:"children"`
+}
+
+type RepositoryConfig struct {
+	// Url of the repository hosting the sources, e.g https://github.com/patrixr/auteur
+	URL    string `yaml:"url" json:"url" toml:"url"`
+	Branch string `yaml:"branch" json:"branch" toml:"branch"`
+	// Folder of the repository the file paths are relative to, defaults to the git root of the root folder
+	Dir string `yaml:"dir" json:"dir" toml:"dir"`
+	// Url patterns of the edit and view source links, detected from the host of GitHub and GitLab repositories.
+	// Patterns can use the {url}, {branch}, {path}, {start} and {end} placeholders
+	EditURL   string `yaml:"editURL" json:"editURL" toml:"editURL"`
+	SourceURL string `yaml:"sourceURL" json:"sourceURL" toml:"sourceURL"`
+}
+
+type FrontmatterConfig struct {
+	// Unknown frontmatter keys fail the build instead of logging a warning
+	Strict bool `yaml:"strict" json:"strict" toml:"strict"`
+	// Additional keys allowed in the frontmatter, e.g keys read by a custom theme
+	Keys []string `yaml:"keys" json:"keys" toml:"keys"`
 }
 
 type AuteurConfig struct {
-	Exclude   []string `yaml:"exclude"`
-	Title     string   `yaml:"title"`
-	Desc      string   `yaml:"desc"`
-	Version   string   `yaml:"version"`
-	Outfolder string   `yaml:"outfolder"`
-	Rootdir   string   `yaml:"root"`
-	Webroot   string   `yaml:"webroot"`
-	Links     []Link   `yaml:"links"`
-	Priority  int      `yaml:"priority"`
-	Theme     string   `yaml:"theme"`
+	Exclude   []string `yaml:"exclude" json:"exclude" toml:"exclude"`
+	Title     string   `yaml:"title" json:"title" toml:"title"`
+	Desc      string   `yaml:"desc" json:"desc" toml:"desc"`
+	Version   string   `yaml:"version" json:"version" toml:"version"`
+	Outfolder string   `yaml:"outfolder" json:"outfolder" toml:"outfolder"`
+	Rootdir   string   `yaml:"root" json:"root" toml:"root"`
+	Webroot   string   `yaml:"webroot" json:"webroot" toml:"webroot"`
+	BaseURL   string   `yaml:"baseURL" json:"baseURL" toml:"baseURL"`
+	Links     []Link   `yaml:"links" json:"links" toml:"links"`
+	Priority  int      `yaml:"priority" json:"priority" toml:"priority"`
+	Theme     string   `yaml:"theme" json:"theme" toml:"theme"`
+	ThemeDir  string   `yaml:"themedir" json:"themedir" toml:"themedir"`
+
+	// Author of the site, named in its feed. Defaults to the title
+	Author string `yaml:"author" json:"author" toml:"author"`
+
+	// Names of the processors used to ingest files
+	Processors []string `yaml:"processors" json:"processors" toml:"processors"`
+
+	// Patterns of the files to ingest, every file that isn't excluded is ingested when empty
+	Include []string `yaml:"include" json:"include" toml:"include"`
+
+	// Honour the .gitignore and .auteurignore files of every folder
+	IgnoreFiles bool `yaml:"ignoreFiles" json:"ignoreFiles" toml:"ignoreFiles"`
+
+	// Folders ingested into the site, replacing the root folder when set
+	SourceFolders []SourceConfig `yaml:"sources" json:"sources" toml:"sources"`
+
+	OpenAPI OpenAPIConfig `yaml:"openapi" json:"openapi" toml:"openapi"`
+
+	Highlight HighlightConfig `yaml:"highlight" json:"highlight" toml:"highlight"`
+
+	Frontmatter FrontmatterConfig `yaml:"frontmatter" json:"frontmatter" toml:"frontmatter"`
+
+	Robots RobotsConfig `yaml:"robots" json:"robots" toml:"robots"`
+
+	// Versions of the documentation built side by side, the first one is the default
+	Versions []VersionConfig `yaml:"versions" json:"versions" toml:"versions"`
+
+	// Webroot shared by every version of the site, set when building one of them
+	VersionsWebroot string `yaml:"-" json:"-" toml:"-"`
+
+	// Repository the sources are hosted in, used to link pages to their source files
+	Repository RepositoryConfig `yaml:"repository" json:"repository" toml:"repository"`
+
+	// Navigation of the site, replacing the tree inferred from the sources when set
+	Nav []NavItem `yaml:"nav" json:"nav" toml:"nav"`
+
+	// File the navigation is read from, instead of the nav section
+	NavFile string `yaml:"navfile" json:"navfile" toml:"navfile"`
+
+	// Locales the site is translated to, the first one is the default locale
+	Locales []string `yaml:"locales" json:"locales" toml:"locales"`
+
+	// Locale of the site, set when building one of its translations
+	Lang string `yaml:"-" json:"-" toml:"-"`
+
+	// Incremental builds reuse the output of the previous build
+	// and only re-render pages affected by changed files
+	Incremental bool `yaml:"incremental" json:"incremental" toml:"incremental"`
+
+	// Offline builds reference the third-party assets embedded in the binary
+	// instead of loading them from CDNs
+	Offline bool `yaml:"offline" json:"offline" toml:"offline"`
+
+	// Number of files loaded concurrently during ingestion, defaults to the number of CPUs
+	Workers int `yaml:"workers" json:"workers" toml:"workers"`
+}
+
+// DefaultExclude are the files excluded when the configuration doesn't list any
+var DefaultExclude = []string{
+	"node_modules",
+	".git",
+	".gitignore",
+	".DS_Store",
+	"*_test.go",
 }
 
 // ExtendConfig returns a new AuteurConfig with the values of the other config
@@ -35,8 +184,13 @@ func (ac AuteurConfig) ExtendConfig(other *AuteurConfig) AuteurConfig {
 		return ac
 	}
 
+	// Lists are copied, they are shared with the config being extended
 	if other.Exclude != nil {
-		ac.Exclude = append(ac.Exclude, other.Exclude...)
+		ac.Exclude = append(slices.Clip(ac.Exclude), other.Exclude...)
+	}
+
+	if other.Links != nil {
+		ac.Links = append(slices.Clip(ac.Links), other.Links...)
 	}
 
 	if other.Title != "" {
@@ -47,6 +201,10 @@ func (ac AuteurConfig) ExtendConfig(other *AuteurConfig) AuteurConfig {
 		ac.Theme = other.Theme
 	}
 
+	if other.ThemeDir != "" {
+		ac.ThemeDir = other.ThemeDir
+	}
+
 	if other.Desc != "" {
 		ac.Desc = other.Desc
 	}
@@ -74,10 +232,56 @@ func (ac AuteurConfig) ExtendConfig(other *AuteurConfig) AuteurConfig {
 	return ac
 }
 
+// ForVersion returns the configuration used to build one of the versions of the site.
+// Each version is rendered into its own subfolder of the output folder and of the webroot
+func (ac AuteurConfig) ForVersion(version VersionConfig) AuteurConfig {
+	ac.VersionsWebroot = ac.Webroot
+	ac.Version = version.Name
+	ac.Outfolder = filepath.Join(ac.Outfolder, version.Name)
+	ac.Webroot = path.Join("/", ac.Webroot, version.Name)
+
+	if version.Root != "" {
+		ac.Rootdir = version.Root
+	}
+
+	return ac
+}
+
+// ForLocale returns the configuration used to build one of the translations of the site.
+// Each locale is rendered into its own subfolder of the output folder and of the webroot
+func (ac AuteurConfig) ForLocale(lang string) AuteurConfig {
+	ac.Lang = lang
+	ac.Outfolder = filepath.Join(ac.Outfolder, lang)
+	ac.Webroot = path.Join("/", ac.Webroot, lang)
+	return ac
+}
+
+// DefaultLocale returns the locale of the content that doesn't specify one
+func (ac AuteurConfig) DefaultLocale() string {
+	if len(ac.Locales) == 0 {
+		return ""
+	}
+	return ac.Locales[0]
+}
+
+type ConfigOptions struct {
+	// Configuration file to read, instead of the one detected in the current directory
+	File string
+	// Values of the settings given on the command line, by key
+	Overrides map[string][]string
+}
+
 // DetectConfig reads the configuration file from the current directory and returns
 // an AuteurConfig struct with the values from the configuration file.
 // Environment variables can be used to override the values in the configuration file.
 func DetectConfig() (AuteurConfig, error) {
+	return LoadConfig(ConfigOptions{})
+}
+
+// LoadConfig builds the configuration from its defaults, the configuration file,
+// the AUTEUR_* environment variables and the command line overrides, in increasing order of precedence.
+// Relative paths of the configuration file are resolved from the folder it is in
+func LoadConfig(opts ConfigOptions) (AuteurConfig, error) {
 	config := AuteurConfig{
 		Title:     "Auteur",
 		Desc:      "Static site generated with Auteur",
@@ -86,48 +290,45 @@ func DetectConfig() (AuteurConfig, error) {
 		Webroot:   "/",
 		Version:   "0.0.1",
 		Theme:     "default",
-		Exclude: []string{
-			"node_modules",
-			".git",
-			".gitignore",
-			".DS_Store",
-			"*_test.go",
+		Processors: []string{
+			"comments",
+			"markdown",
+		},
+		OpenAPI: OpenAPIConfig{
+			Path: "api",
 		},
+		Repository: RepositoryConfig{
+			Branch: "main",
+		},
+		Highlight: HighlightConfig{
+			Light: "github",
+			Dark:  "github-dark",
+		},
+		Exclude: append([]string{}, DefaultExclude...),
 	}
 
-	candidates := []string{
-		"auteur.yml",
-		"auteur.yaml",
-		"auteur.json",
+	configFile := opts.File
+	if configFile == "" {
+		configFile = findConfigFile(".")
 	}
 
-	for _, configFile := range candidates {
-		if _, err := os.Stat(configFile); os.IsNotExist(err) {
-			continue
-		}
-
+	if configFile != "" {
 		Log("Configuration detected", "file", configFile)
-		fileContent, err := os.ReadFile(configFile)
-
-		if err != nil && os.IsNotExist(err) {
-			continue
-		}
 
-		if err != nil {
+		if err := readConfigFile(configFile, &config); err != nil {
 			return config, err
 		}
 
-		if err := yaml.Unmarshal(fileContent, &config); err != nil {
-			return config, err
-		}
+		config.resolvePaths(filepath.Dir(configFile))
 	}
 
-	config.Webroot = q.ReadEnv("AUTEUR_WEBROOT", config.Webroot)
-	config.Outfolder = q.ReadEnv("AUTEUR_OUTFOLDER", config.Outfolder)
-	config.Rootdir = q.ReadEnv("AUTEUR_ROOTDIR", config.Rootdir)
-	config.Title = q.ReadEnv("AUTEUR_TITLE", config.Title)
-	config.Version = q.ReadEnv("AUTEUR_VERSION", config.Version)
-	config.Desc = q.ReadEnv("AUTEUR_DESC", config.Desc)
+	if err := applyEnv(&config); err != nil {
+		return config, err
+	}
+
+	if err := applyOverrides(&config, opts.Overrides); err != nil {
+		return config, err
+	}
 
 	absRootdir, err := filepath.Abs(config.Rootdir)
 	if err != nil {
@@ -141,5 +342,78 @@ func DetectConfig() (AuteurConfig, error) {
 	}
 	config.Outfolder = absOutfolder
 
+	if config.Repository.Dir != "" {
+		absRepoDir, err := filepath.Abs(config.Repository.Dir)
+		if err != nil {
+			return config, err
+		}
+		config.Repository.Dir = absRepoDir
+	}
+
+	if config.NavFile != "" {
+		if err := readConfigFile(config.NavFile, &config.Nav); err != nil {
+			return config, fmt.Errorf("invalid nav file: %w", err)
+		}
+	}
+
+	for i, lang := range config.Locales {
+		config.Locales[i] = strings.ToLower(lang)
+	}
+
+	for i, version := range config.Versions {
+		if version.Root == "" {
+			continue
+		}
+
+		absRoot, err := filepath.Abs(version.Root)
+		if err != nil {
+			return config, err
+		}
+		config.Versions[i].Root = absRoot
+	}
+
+	for i, source := range config.SourceFolders {
+		if source.Root == "" {
+			return config, fmt.Errorf("source %d has no root folder", i+1)
+		}
+
+		absRoot, err := filepath.Abs(source.Root)
+		if err != nil {
+			return config, err
+		}
+		config.SourceFolders[i].Root = absRoot
+	}
+
+	if config.ThemeDir != "" {
+		absThemeDir, err := filepath.Abs(config.ThemeDir)
+		if err != nil {
+			return config, err
+		}
+		config.ThemeDir = absThemeDir
+	}
+
 	return config, nil
 }
+
+// resolvePaths makes the relative paths of the configuration relative to the folder
+func (ac *AuteurConfig) resolvePaths(folder string) {
+	resolve := func(path *string) {
+		if *path != "" && !filepath.IsAbs(*path) {
+			*path = filepath.Join(folder, *path)
+		}
+	}
+
+	resolve(&ac.Rootdir)
+	resolve(&ac.Outfolder)
+	resolve(&ac.ThemeDir)
+	resolve(&ac.NavFile)
+	resolve(&ac.Repository.Dir)
+
+	for i := range ac.Versions {
+		resolve(&ac.Versions[i].Root)
+	}
+
+	for i := range ac.SourceFolders {
+		resolve(&ac.SourceFolders[i].Root)
+	}
+}
@@ -0,0 +1,154 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	. "github.com/patrixr/auteur/common"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFiles are the names of the configuration file, in order of precedence
+var ConfigFiles = []string{
+	"auteur.yml",
+	"auteur.yaml",
+	"auteur.json",
+	"auteur.toml",
+}
+
+// findConfigFile returns the configuration file of the folder, or an empty string if there is none.
+// When several are present, the first one in order of precedence is used and the others are ignored
+func findConfigFile(folder string) string {
+	found := []string{}
+
+	for _, name := range ConfigFiles {
+		file := filepath.Join(folder, name)
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			found = append(found, file)
+		}
+	}
+
+	if len(found) == 0 {
+		return ""
+	}
+
+	if len(found) > 1 {
+		LogWarn("Several configuration files found, only the first one is used", "used", found[0], "ignored", strings.Join(found[1:], ", "))
+	}
+
+	return found[0]
+}
+
+// readConfigFile decodes a YAML, JSON or TOML file into the value, depending on its extension.
+// Errors point at the line of the file they occurred on
+func readConfigFile(file string, value interface{}) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(file)); ext {
+	case ".yml", ".yaml":
+		return yamlError(file, yaml.Unmarshal(content, value))
+	case ".json":
+		return jsonError(file, content, json.Unmarshal(content, value))
+	case ".toml":
+		_, err := toml.Decode(string(content), value)
+		return tomlError(file, err)
+	default:
+		return fmt.Errorf("%s: unsupported configuration format %s, expected .yml, .yaml, .json or .toml", file, ext)
+	}
+}
+
+var yamlLineRexp = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// yamlError prefixes the yaml errors with the file and line they occurred on
+func yamlError(file string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	messages := []string{err.Error()}
+
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	}
+
+	errs := []error{}
+
+	for _, message := range messages {
+		if match := yamlLineRexp.FindStringSubmatch(message); match != nil {
+			errs = append(errs, fmt.Errorf("%s:%s: %s", file, match[1], match[2]))
+		} else {
+			errs = append(errs, fmt.Errorf("%s: %s", file, strings.TrimPrefix(message, "yaml: ")))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// jsonError prefixes the json errors with the file, line and column they occurred on
+func jsonError(file string, content []byte, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Errorf("%s:%s: %s", file, position(content, syntaxErr.Offset), syntaxErr)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return fmt.Errorf("%s:%s: invalid value for key %q, expected %s but got %s",
+			file, position(content, typeErr.Offset), typeErr.Field, typeErr.Type, typeErr.Value)
+	}
+
+	return fmt.Errorf("%s: %w", file, err)
+}
+
+var tomlLineRexp = regexp.MustCompile(`^toml: line (\d+) \(last key "(.*)"\): (.*)$`)
+
+// tomlError prefixes the toml errors with the file and line they occurred on
+func tomlError(file string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var parseErr toml.ParseError
+	if errors.As(err, &parseErr) {
+		message := parseErr.Message
+		if parseErr.LastKey != "" {
+			message = fmt.Sprintf("%s (key %q)", message, parseErr.LastKey)
+		}
+		return fmt.Errorf("%s:%d:%d: %s", file, parseErr.Position.Line, parseErr.Position.Col, message)
+	}
+
+	// Decoding errors only carry their position in their message
+	if match := tomlLineRexp.FindStringSubmatch(err.Error()); match != nil {
+		return fmt.Errorf("%s:%s: %s (key %q)", file, match[1], match[3], match[2])
+	}
+
+	return fmt.Errorf("%s: %s", file, strings.TrimPrefix(err.Error(), "toml: "))
+}
+
+// position returns the line and column of a byte offset of the content
+func position(content []byte, offset int64) string {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+
+	before := string(content[:offset])
+	line := strings.Count(before, "\n") + 1
+	col := len(before) - strings.LastIndex(before, "\n")
+
+	return strconv.Itoa(line) + ":" + strconv.Itoa(col)
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigFiles(t *testing.T) {
+	folder := t.TempDir()
+
+	write := func(name string, content string) string {
+		file := filepath.Join(folder, name)
+		os.WriteFile(file, []byte(content), 0644)
+		return file
+	}
+
+	files := map[string]string{
+		"auteur.yaml": "title: Docs\nworkers: 2\nexclude: [drafts]\nhighlight:\n  dark: monokai\nversions:\n  - name: v1\n    ref: v1.0.0\n",
+		"auteur.json": `{"title": "Docs", "workers": 2, "exclude": ["drafts"], "highlight": {"dark": "monokai"}, "versions": [{"name": "v1", "ref": "v1.0.0"}]}`,
+		"auteur.toml": "title = \"Docs\"\nworkers = 2\nexclude = [\"drafts\"]\n\n[highlight]\ndark = \"monokai\"\n\n[[versions]]\nname = \"v1\"\nref = \"v1.0.0\"\n",
+	}
+
+	for name, content := range files {
+		t.Run("Decodes "+name, func(t *testing.T) {
+			config := AuteurConfig{Exclude: []string{"node_modules"}, Highlight: HighlightConfig{Light: "github"}}
+
+			assert.NoError(t, readConfigFile(write(name, content), &config))
+			assert.Equal(t, "Docs", config.Title)
+			assert.Equal(t, 2, config.Workers)
+			assert.Equal(t, []string{"drafts"}, config.Exclude)
+			assert.Equal(t, HighlightConfig{Light: "github", Dark: "monokai"}, config.Highlight)
+			assert.Equal(t, []VersionConfig{{Name: "v1", Ref: "v1.0.0"}}, config.Versions)
+		})
+	}
+
+	t.Run("The first file in order of precedence is used", func(t *testing.T) {
+		assert.Equal(t, filepath.Join(folder, "auteur.yaml"), findConfigFile(folder))
+		assert.Equal(t, "", findConfigFile(t.TempDir()))
+	})
+
+	invalid := map[string]string{
+		"invalid.yaml": "title: Docs\nworkers: four\n",
+		"invalid.json": "{\n  \"title\": \"Docs\",\n  \"workers\": \"four\"\n}",
+		"invalid.toml": "title = \"Docs\"\nworkers = \"four\"\n",
+	}
+
+	expected := map[string]string{
+		"invalid.yaml": ":2: ",
+		"invalid.json": ":3:20: ",
+		"invalid.toml": ":2: ",
+	}
+
+	for name, content := range invalid {
+		t.Run("Errors of "+name+" point at their line", func(t *testing.T) {
+			file := write(name, content)
+			err := readConfigFile(file, &AuteurConfig{})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), file+expected[name])
+		})
+	}
+
+	t.Run("Unknown formats are rejected", func(t *testing.T) {
+		assert.Error(t, readConfigFile(write("auteur.ini", "title=Docs"), &AuteurConfig{}))
+	})
+}
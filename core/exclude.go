@@ -0,0 +1,250 @@
+package core
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/bmatcuk/doublestar/v4"
+	. "github.com/patrixr/auteur/common"
+)
+
+// IgnoreFileNames are the ignore files read in every folder when the ignoreFiles setting is enabled
+var IgnoreFileNames = []string{
+	".gitignore",
+	".auteurignore",
+}
+
+// ExcludeRule is an exclude pattern, following the syntax of .gitignore files:
+//   - patterns without a slash match the name of files and folders at any depth, e.g *.tmp
+//   - other patterns match the path relative to the folder of the rule, with ** matching any number of folders, e.g services/*/vendor
+//   - a trailing slash only matches folders, e.g build/
+//   - a leading ! re-includes the paths excluded by the previous rules, e.g !keep.md
+type ExcludeRule struct {
+	Pattern string
+	// Folder the pattern is relative to
+	Base     string
+	Negate   bool
+	DirOnly  bool
+	Anchored bool
+}
+
+// ParseExcludeRules parses the patterns relative to the base folder, blank lines and # comments are skipped
+func ParseExcludeRules(patterns []string, base string) []ExcludeRule {
+	rules := []ExcludeRule{}
+
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+
+		rule := ExcludeRule{Base: base}
+
+		if strings.HasPrefix(pattern, "!") {
+			rule.Negate = true
+			pattern = pattern[1:]
+		} else if strings.HasPrefix(pattern, `\!`) || strings.HasPrefix(pattern, `\#`) {
+			pattern = pattern[1:]
+		}
+
+		if strings.HasSuffix(pattern, "/") {
+			rule.DirOnly = true
+			pattern = strings.TrimRight(pattern, "/")
+		}
+
+		if strings.Contains(pattern, "/") {
+			rule.Anchored = true
+			pattern = strings.TrimPrefix(pattern, "/")
+		}
+
+		if pattern == "" {
+			continue
+		}
+
+		rule.Pattern = pattern
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// Matches returns true if the rule matches the path, regardless of its negation.
+// Paths outside of the folder of the rule never match
+func (rule ExcludeRule) Matches(path string, isDir bool) bool {
+	if rule.DirOnly && !isDir {
+		return false
+	}
+
+	rel, err := filepath.Rel(rule.Base, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+
+	rel = filepath.ToSlash(rel)
+
+	if !rule.Anchored {
+		name := filepath.Base(path)
+		matched, _ := doublestar.Match(rule.Pattern, name)
+		return matched || rule.Pattern == name
+	}
+
+	matched, _ := doublestar.Match(rule.Pattern, rel)
+
+	// A trailing /** matches what's inside the folder, not the folder itself
+	if matched && strings.HasSuffix(rule.Pattern, "/**") {
+		folder, _ := doublestar.Match(strings.TrimSuffix(rule.Pattern, "/**"), rel)
+		return !folder
+	}
+
+	return matched || rule.Pattern == rel
+}
+
+// matchRules returns true if the last rule matching the path isn't negated
+func matchRules(rules []ExcludeRule, path string, isDir bool) bool {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].Matches(path, isDir) {
+			return !rules[i].Negate
+		}
+	}
+	return false
+}
+
+// IsExcluded returns true if the file name matches the patterns, see ExcludeRule for their syntax
+func IsExcluded(filename string, patterns []string) bool {
+	return matchRules(ParseExcludeRules(patterns, "."), filename, false)
+}
+
+// Exclusion decides which files of a folder are left out of the site,
+// from the exclude and include settings and, optionally, the ignore files of every folder
+type Exclusion struct {
+	root        string
+	rules       []ExcludeRule
+	include     []ExcludeRule
+	ignoreFiles bool
+	mutex       sync.Mutex
+	// Rules added by the subfolders, from their ignore files and configuration files
+	folders map[string][]ExcludeRule
+}
+
+// NewExclusion creates the exclusion of the root folder. Exclude and include patterns are relative to it.
+// When include patterns are given, only the files matching one of them are kept
+func NewExclusion(root string, exclude []string, include []string, ignoreFiles bool) *Exclusion {
+	if abs, err := filepath.Abs(root); err == nil {
+		root = abs
+	}
+
+	return &Exclusion{
+		root:        root,
+		rules:       ParseExcludeRules(exclude, root),
+		include:     ParseExcludeRules(include, root),
+		ignoreFiles: ignoreFiles,
+		folders:     map[string][]ExcludeRule{},
+	}
+}
+
+// AddRules adds exclude patterns relative to a subfolder, they take precedence over the ones of its parents
+func (e *Exclusion) AddRules(folder string, patterns []string) {
+	rules := e.folderRules(folder)
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.folders[folder] = append(rules, ParseExcludeRules(patterns, folder)...)
+}
+
+// Excluded returns true if the file or folder is left out of the site.
+// Its parent folders aren't checked, they are expected to have been walked through already
+func (e *Exclusion) Excluded(path string, isDir bool) bool {
+	rules := e.rules
+
+	for _, folder := range e.parents(path) {
+		rules = append(rules[:len(rules):len(rules)], e.folderRules(folder)...)
+	}
+
+	if matchRules(rules, path, isDir) {
+		return true
+	}
+
+	// Folders are walked through to find the included files
+	return !isDir && len(e.include) > 0 && !matchRules(e.include, path, false)
+}
+
+// ExcludedPath returns true if the file or folder, or one of its parent folders, is left out of the site
+func (e *Exclusion) ExcludedPath(path string, isDir bool) bool {
+	for _, folder := range e.parents(path)[1:] {
+		if e.Excluded(folder, true) {
+			return true
+		}
+	}
+
+	return e.Excluded(path, isDir)
+}
+
+// parents returns the folders from the root folder to the parent folder of the path
+func (e *Exclusion) parents(path string) []string {
+	rel, err := filepath.Rel(e.root, filepath.Dir(path))
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return []string{e.root}
+	}
+
+	folders := []string{e.root}
+	folder := e.root
+
+	if rel != "." {
+		for _, part := range strings.Split(rel, string(filepath.Separator)) {
+			folder = filepath.Join(folder, part)
+			folders = append(folders, folder)
+		}
+	}
+
+	return folders
+}
+
+// folderRules returns the rules added by the folder, its ignore files are read the first time
+func (e *Exclusion) folderRules(folder string) []ExcludeRule {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if rules, ok := e.folders[folder]; ok {
+		return rules
+	}
+
+	rules := []ExcludeRule{}
+
+	if e.ignoreFiles {
+		for _, name := range IgnoreFileNames {
+			rules = append(rules, readIgnoreFile(filepath.Join(folder, name))...)
+		}
+	}
+
+	e.folders[folder] = rules
+	return rules
+}
+
+// readIgnoreFile returns the rules of an ignore file, or none if it doesn't exist
+func readIgnoreFile(file string) []ExcludeRule {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	LogDebug("Reading ignore file", "file", file)
+
+	patterns := []string{}
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		patterns = append(patterns, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		LogWarn("Unable to read ignore file", "file", file, "err", err)
+	}
+
+	return ParseExcludeRules(patterns, filepath.Dir(file))
+}
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExcludeRules(t *testing.T) {
+	root := t.TempDir()
+	path := func(rel string) string {
+		return filepath.Join(root, filepath.FromSlash(rel))
+	}
+
+	cases := []struct {
+		pattern string
+		path    string
+		isDir   bool
+		matches bool
+	}{
+		{"*.tmp", "notes.tmp", false, true},
+		{"*.tmp", "a/b/notes.tmp", false, true},
+		{"node_modules", "web/node_modules", true, true},
+		{"services/*/vendor", "services/api/vendor", true, true},
+		{"services/*/vendor", "vendor", true, false},
+		{"services/*/vendor", "other/services/api/vendor", true, false},
+		{"/docs", "docs", true, true},
+		{"/docs", "a/docs", true, false},
+		{"**/generated/**", "a/generated/b/file.go", false, true},
+		{"**/generated/**", "generated/file.go", false, true},
+		{"**/generated/**", "a/generated", true, false},
+		{"build/", "build", true, true},
+		{"build/", "build", false, false},
+		{"!keep.md", "a/keep.md", false, true},
+	}
+
+	for _, c := range cases {
+		rules := ParseExcludeRules([]string{c.pattern}, root)
+		assert.Len(t, rules, 1)
+		assert.Equal(t, c.matches, rules[0].Matches(path(c.path), c.isDir), "%s on %s", c.pattern, c.path)
+	}
+
+	t.Run("Comments and blank lines are skipped", func(t *testing.T) {
+		assert.Empty(t, ParseExcludeRules([]string{"", "  ", "# comment"}, root))
+		assert.Equal(t, "#file", ParseExcludeRules([]string{`\#file`}, root)[0].Pattern)
+	})
+
+	t.Run("Paths outside of the folder of the rule don't match", func(t *testing.T) {
+		rule := ParseExcludeRules([]string{"*.md"}, path("docs"))[0]
+		assert.True(t, rule.Matches(path("docs/a.md"), false))
+		assert.False(t, rule.Matches(path("a.md"), false))
+	})
+
+	t.Run("File names are matched by IsExcluded", func(t *testing.T) {
+		assert.True(t, IsExcluded("main_test.go", DefaultExclude))
+		assert.False(t, IsExcluded("main.go", DefaultExclude))
+		assert.False(t, IsExcluded("keep.tmp", []string{"*.tmp", "!keep.tmp"}))
+	})
+}
+
+func TestExclusion(t *testing.T) {
+	root := t.TempDir()
+	path := func(rel string) string {
+		return filepath.Join(root, filepath.FromSlash(rel))
+	}
+
+	os.MkdirAll(path("docs/internal"), 0755)
+	os.WriteFile(path(".gitignore"), []byte("# build output\n*.log\n/dist/\n"), 0644)
+	os.WriteFile(path("docs/.auteurignore"), []byte("internal/\n!debug.log\n"), 0644)
+
+	t.Run("Negated patterns re-include files", func(t *testing.T) {
+		exclusion := NewExclusion(root, []string{"*.md", "!README.md"}, nil, false)
+		assert.True(t, exclusion.Excluded(path("guide.md"), false))
+		assert.False(t, exclusion.Excluded(path("README.md"), false))
+	})
+
+	t.Run("Only included files are kept", func(t *testing.T) {
+		exclusion := NewExclusion(root, []string{"drafts"}, []string{"*.md", "api/**/*.go"}, false)
+		assert.False(t, exclusion.Excluded(path("guide.md"), false))
+		assert.False(t, exclusion.Excluded(path("api/v1/handler.go"), false))
+		assert.True(t, exclusion.Excluded(path("main.go"), false))
+		assert.False(t, exclusion.Excluded(path("src"), true))
+		assert.True(t, exclusion.ExcludedPath(path("drafts/guide.md"), false))
+	})
+
+	t.Run("Ignore files are only read when enabled", func(t *testing.T) {
+		exclusion := NewExclusion(root, nil, nil, false)
+		assert.False(t, exclusion.Excluded(path("server.log"), false))
+		assert.False(t, exclusion.Excluded(path("dist"), true))
+	})
+
+	t.Run("Ignore files apply to their folder", func(t *testing.T) {
+		exclusion := NewExclusion(root, nil, nil, true)
+		assert.True(t, exclusion.Excluded(path("server.log"), false))
+		assert.True(t, exclusion.Excluded(path("docs/server.log"), false))
+		assert.True(t, exclusion.Excluded(path("dist"), true))
+		assert.False(t, exclusion.Excluded(path("docs/dist"), true))
+		assert.True(t, exclusion.Excluded(path("docs/internal"), true))
+		assert.False(t, exclusion.Excluded(path("internal"), true))
+	})
+
+	t.Run("Nested ignore files take precedence", func(t *testing.T) {
+		exclusion := NewExclusion(root, nil, nil, true)
+		assert.False(t, exclusion.Excluded(path("docs/debug.log"), false))
+		assert.True(t, exclusion.Excluded(path("debug.log"), false))
+	})
+
+	t.Run("Files of excluded folders are excluded", func(t *testing.T) {
+		exclusion := NewExclusion(root, nil, nil, true)
+		assert.False(t, exclusion.Excluded(path("docs/internal/notes.md"), false))
+		assert.True(t, exclusion.ExcludedPath(path("docs/internal/notes.md"), false))
+	})
+}
+
+func TestIngestExclusion(t *testing.T) {
+	rootdir := t.TempDir()
+
+	for _, file := range []string{"one.txt", "services/api/vendor/dep.txt", "services/api/two.txt", "generated/three.txt", "notes/four.txt"} {
+		os.MkdirAll(filepath.Join(rootdir, filepath.Dir(file)), 0755)
+		os.WriteFile(filepath.Join(rootdir, file), []byte(file), 0644)
+	}
+	os.WriteFile(filepath.Join(rootdir, ".auteurignore"), []byte("notes/\n"), 0644)
+
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+	site.Rootdir = rootdir
+	site.Exclude = []string{"services/*/vendor", "**/generated/**"}
+	site.IgnoreFiles = true
+	site.RegisterProcessor(FolderProcessor{})
+
+	files, err := site.collectFiles(rootdir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(rootdir, "one.txt"),
+		filepath.Join(rootdir, "services", "api", "two.txt"),
+	}, files)
+}
@@ -0,0 +1,305 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/patrixr/auteur/common"
+)
+
+// collectFiles walks the folder, or the sources of the configuration, and returns in traversal order
+// every file that isn't excluded and is supported by a processor.
+// The configuration files of their subfolders are recorded along the way
+func (site *Auteur) collectFiles(infolder string) ([]string, error) {
+	site.scopes = nil
+
+	roots, err := site.resolveRoots(infolder)
+	if err != nil {
+		return nil, err
+	}
+
+	site.roots = roots
+	collected := []string{}
+
+	for _, root := range roots {
+		files, err := site.collectFolder(root, root.root)
+		if err != nil {
+			return nil, err
+		}
+
+		// Files of nested sources are only ingested once, by their innermost source
+		for _, file := range files {
+			if site.rootOf(file) == root {
+				collected = append(collected, file)
+			}
+		}
+	}
+
+	return collected, nil
+}
+
+func (site *Auteur) collectFolder(root *sourceRoot, infolder string) ([]string, error) {
+	files, err := os.ReadDir(infolder)
+
+	if err != nil {
+		return nil, err
+	}
+
+	collected := []string{}
+
+	for _, file := range files {
+		abspath, err := filepath.Abs(filepath.Join(infolder, file.Name()))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if root.exclusion.Excluded(abspath, file.IsDir()) {
+			common.Log("Excluding " + abspath)
+			continue
+		}
+
+		// Recurse into directories
+		if file.IsDir() {
+			if err := site.readScope(root, abspath); err != nil {
+				return nil, err
+			}
+
+			nested, err := site.collectFolder(root, abspath)
+			if err != nil {
+				return nil, err
+			}
+			collected = append(collected, nested...)
+			continue
+		}
+
+		if supports(root.processors, filepath.Ext(abspath)) {
+			collected = append(collected, abspath)
+		}
+	}
+
+	return collected, nil
+}
+
+// loadFiles runs the processors over the files using a bounded pool of workers.
+// The result at index i holds the content loaded from files[i]
+func (site *Auteur) loadFiles(files []string) ([][]Content, error) {
+	results, errs := site.loadEach(files)
+
+	// Report the first error in traversal order to remain deterministic
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// loadEach loads every file concurrently, returning the content and error of each file
+func (site *Auteur) loadEach(files []string) ([][]Content, []error) {
+	results := make([][]Content, len(files))
+	errs := make([]error, len(files))
+
+	workers := site.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	queue := make(chan int)
+	wg := sync.WaitGroup{}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				results[i], errs[i] = site.loadFile(files[i])
+			}
+		}()
+	}
+
+	for i := range files {
+		queue <- i
+	}
+
+	close(queue)
+	wg.Wait()
+
+	return results, errs
+}
+
+// loadFile returns the content of a single file, either from the build cache
+// or by running every processor supporting its extension
+func (site *Auteur) loadFile(abspath string) ([]Content, error) {
+	ext := filepath.Ext(abspath)
+
+	var hash string
+
+	if site.cache != nil {
+		var err error
+		if hash, err = HashFile(abspath, site.dependencies(abspath)...); err != nil {
+			return nil, err
+		}
+
+		if contents, ok := site.cache.Lookup(abspath, hash); ok {
+			common.LogDebug("Unchanged " + abspath)
+			return site.mountContents(abspath, contents), nil
+		}
+	}
+
+	contents := []Content{}
+
+	for _, processor := range site.processorsOf(abspath) {
+		if !processor.Supports(ext) {
+			continue
+		}
+
+		loaded, err := processor.Load(site, abspath)
+
+		if err != nil {
+			return nil, err
+		}
+
+		contents = append(contents, loaded...)
+	}
+
+	if site.cache != nil {
+		site.cache.Store(abspath, hash, contents)
+	}
+
+	return site.mountContents(abspath, contents), nil
+}
+
+// addFileContents merges the content of a file into the page tree.
+// When building a translation of the site, only the content written in its locale
+// is added, content of the default locale is returned to be used as a fallback.
+// It must not be called concurrently
+func (site *Auteur) addFileContents(file string, contents []Content) []Content {
+	fallbacks := []Content{}
+
+	for _, content := range contents {
+		if locale := site.localeOf(content); locale != site.Lang {
+			if locale == site.DefaultLocale() {
+				fallbacks = append(fallbacks, content)
+			}
+			continue
+		}
+
+		site.addFileContent(file, content)
+	}
+
+	return fallbacks
+}
+
+// addFallbacks adds the content of the default locale to the pages
+// that have no translation in the locale of the site
+func (site *Auteur) addFallbacks(files []string, fallbacks [][]Content) {
+	translated := map[string]bool{}
+
+	var traverse func(page *Auteur)
+	traverse = func(page *Auteur) {
+		if len(page.Content) > 0 {
+			translated[page.Href()] = true
+		}
+		for _, child := range page.children {
+			traverse(child)
+		}
+	}
+
+	traverse(site)
+
+	for i, file := range files {
+		for _, content := range fallbacks[i] {
+			if translated[site.hrefOf(content)] {
+				continue
+			}
+			site.addFileContent(file, content)
+		}
+	}
+}
+
+func (site *Auteur) addFileContent(file string, content Content) {
+	page := site.AddContent(content)
+
+	if page == nil {
+		return
+	}
+
+	if !slices.Contains(page.sources, file) {
+		page.sources = append(page.sources, file)
+	}
+}
+
+// recordContributions records the pages each file contributed to in the build cache,
+// once the page tree has reached its final shape
+func (site *Auteur) recordContributions(cache *BuildCache) {
+	for _, file := range site.sources {
+		cache.Contributed(file, site.Href())
+	}
+
+	for _, child := range site.children {
+		child.recordContributions(cache)
+	}
+}
+
+// localeOf returns the locale of the content, or an empty string
+// if the site isn't translated
+func (site *Auteur) localeOf(content Content) string {
+	if site.Lang == "" {
+		return ""
+	}
+
+	if locale := content.Locale(); locale != "" {
+		return locale
+	}
+
+	return site.DefaultLocale()
+}
+
+// hrefOf returns the href of the page the content is added to
+func (site *Auteur) hrefOf(content Content) string {
+	return hrefOfPath(content.Path())
+}
+
+// hrefOfPath returns the href of the page at the given path
+func hrefOfPath(path []string) string {
+	parts := []string{}
+
+	for _, part := range path {
+		if len(strings.Trim(part, " \t\n")) == 0 {
+			continue
+		}
+		parts = append(parts, common.ToSlug(part))
+	}
+
+	return "/" + strings.Join(parts, "/")
+}
+
+// dependencies returns the other files the content of a file depends on,
+// as reported by the processors supporting it
+func (site *Auteur) dependencies(file string) []string {
+	ext := filepath.Ext(file)
+	deps := []string{}
+
+	for _, processor := range site.processorsOf(file) {
+		if dependent, ok := processor.(DependentProcessor); ok && processor.Supports(ext) {
+			deps = append(deps, dependent.Dependencies(site, file)...)
+		}
+	}
+
+	return deps
+}
+
+func supports(processors []Processor, ext string) bool {
+	for _, processor := range processors {
+		if processor.Supports(ext) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package core
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/patrixr/auteur/common"
+)
+
+// ApplyNav reshapes the page tree following the navigation configuration.
+// Listed pages are moved under their nav parent, in the declared order, items
+// without a page create new sections. Pages with content that are no longer
+// reachable from the navigation are dropped from the site with a warning
+func (site *Auteur) ApplyNav(items []NavItem) {
+	pages := map[string]*Auteur{}
+	before := []*Auteur{}
+	hrefs := map[*Auteur]string{}
+
+	var index func(page *Auteur)
+	index = func(page *Auteur) {
+		pages[page.Href()] = page
+		hrefs[page] = page.Href()
+		before = append(before, page)
+		for _, child := range page.children {
+			index(child)
+		}
+	}
+
+	index(site)
+
+	placed := map[*Auteur]bool{site: true}
+	site.children = site.navChildren(items, pages, placed)
+
+	reachable := map[*Auteur]bool{}
+
+	var traverse func(page *Auteur)
+	traverse = func(page *Auteur) {
+		reachable[page] = true
+		for _, child := range page.children {
+			traverse(child)
+		}
+	}
+
+	traverse(site)
+
+	for _, page := range before {
+		if len(page.Content) == 0 {
+			continue
+		}
+
+		if !reachable[page] {
+			common.LogWarn("Page isn't reachable from the nav and won't be rendered", "page", hrefs[page])
+		} else if page.Href() != hrefs[page] {
+			common.LogWarn("Nav moves the page to a new url, links to the previous one will break", "from", hrefs[page], "to", page.Href())
+		}
+	}
+}
+
+// navChildren returns the pages declared by the nav items, attached to the site
+func (site *Auteur) navChildren(items []NavItem, pages map[string]*Auteur, placed map[*Auteur]bool) []*Auteur {
+	children := []*Auteur{}
+	slugs := map[string]bool{}
+
+	for _, item := range items {
+		var page *Auteur
+
+		if item.Page != "" {
+			href := "/" + strings.Trim(item.Page, "/")
+
+			page = pages[href]
+			if page == nil || page.IsRoot() {
+				common.LogWarn("Nav item references an unknown page", "page", item.Page)
+				continue
+			}
+		} else if item.Title != "" {
+			page = &Auteur{AuteurConfig: site.ExtendConfig(&AuteurConfig{Title: item.Title})}
+		} else {
+			common.LogWarn("Nav items need a page or a title")
+			continue
+		}
+
+		if placed[page] {
+			common.LogWarn("Page is listed more than once in the nav", "page", item.Page)
+			continue
+		}
+
+		placed[page] = true
+
+		// Detach the page from its inferred parent, so it isn't listed twice
+		if page.parent != nil {
+			page.parent.children = slices.DeleteFunc(page.parent.children, func(child *Auteur) bool {
+				return child == page
+			})
+		}
+
+		page.parent = site
+		page.root = site.Root()
+
+		// Titles only relabel ingested pages, their url stays the same so that links keep working
+		if item.Title != "" {
+			if item.Page != "" && page.slug == "" {
+				page.slug = page.Slug()
+			}
+			page.Title = item.Title
+		}
+
+		if item.Icon != "" {
+			page.Icon = item.Icon
+		}
+
+		if item.Collapsed != nil {
+			page.Expanded = !*item.Collapsed
+		}
+
+		if item.Children != nil {
+			page.children = page.navChildren(item.Children, pages, placed)
+		}
+
+		// Siblings sharing a slug are served at the same url, only one of them can be rendered
+		if slugs[page.Slug()] {
+			common.LogWarn("Nav items share the same url", "href", page.Href())
+		}
+
+		slugs[page.Slug()] = true
+		children = append(children, page)
+	}
+
+	return children
+}
@@ -0,0 +1,233 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigField is a setting of the configuration that can be overridden
+// by an environment variable or a command line flag
+type ConfigField struct {
+	// Key of the setting in the configuration file, nested keys are separated by dots e.g highlight.dark
+	Key   string
+	index []int
+	kind  reflect.Type
+}
+
+// Environment variables read before the generic AUTEUR_<KEY> ones, kept for compatibility
+var legacyEnv = map[string]string{
+	"root": "AUTEUR_ROOTDIR",
+}
+
+// Lists the overrides are added to, instead of replacing them, so that the defaults keep applying
+var appendedLists = map[string]bool{
+	"exclude": true,
+}
+
+// Settings without overrides, the priority of the root page has no effect
+var fixedSettings = map[string]bool{
+	"priority": true,
+}
+
+// ConfigFields returns every setting of the configuration file that can be overridden, in declaration order
+func ConfigFields() []ConfigField {
+	return configFields(reflect.TypeOf(AuteurConfig{}), "", nil)
+}
+
+func configFields(t reflect.Type, prefix string, index []int) []ConfigField {
+	fields := []ConfigField{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+
+		if name == "" || name == "-" {
+			continue
+		}
+
+		key := prefix + name
+		if fixedSettings[key] {
+			continue
+		}
+
+		fieldIndex := append(append([]int{}, index...), i)
+
+		if field.Type.Kind() == reflect.Struct {
+			fields = append(fields, configFields(field.Type, key+".", fieldIndex)...)
+			continue
+		}
+
+		fields = append(fields, ConfigField{Key: key, index: fieldIndex, kind: field.Type})
+	}
+
+	return fields
+}
+
+// Flag returns the name of the command line flag of the setting, e.g highlight-dark
+func (field ConfigField) Flag() string {
+	var sb strings.Builder
+	var previous rune
+
+	for _, r := range field.Key {
+		switch {
+		case r == '.':
+			sb.WriteRune('-')
+		case unicode.IsUpper(r) && unicode.IsLower(previous):
+			sb.WriteRune('-')
+			sb.WriteRune(unicode.ToLower(r))
+		default:
+			sb.WriteRune(unicode.ToLower(r))
+		}
+		previous = r
+	}
+
+	return sb.String()
+}
+
+// Env returns the name of the environment variable of the setting, e.g AUTEUR_HIGHLIGHT_DARK
+func (field ConfigField) Env() string {
+	return "AUTEUR_" + strings.ToUpper(strings.ReplaceAll(field.Key, ".", "_"))
+}
+
+// Appends returns true if the overrides of the setting are added to its current value instead of replacing it
+func (field ConfigField) Appends() bool {
+	return appendedLists[field.Key]
+}
+
+// Type describes the values the setting accepts: string, bool, int, list or yaml
+func (field ConfigField) Type() string {
+	switch field.kind.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int:
+		return field.kind.Kind().String()
+	case reflect.Slice:
+		if field.kind.Elem().Kind() == reflect.String {
+			return "list"
+		}
+	}
+	return "yaml"
+}
+
+// Set overrides the setting with the given values, replacing the value of the configuration file.
+// Lists accept comma separated values, settings made of objects accept YAML or JSON values.
+// Every value is appended to lists, the last value is used otherwise.
+// The exclude list keeps its current patterns, the overrides are added to them
+func (field ConfigField) Set(config *AuteurConfig, values []string) error {
+	target := reflect.ValueOf(config).Elem().FieldByIndex(field.index)
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	last := values[len(values)-1]
+	invalid := func(value string, err error) error {
+		return fmt.Errorf("invalid value %q for %s: %w", value, field.Key, err)
+	}
+
+	switch field.Type() {
+	case "string":
+		target.SetString(last)
+
+	case "bool":
+		value, err := strconv.ParseBool(last)
+		if err != nil {
+			return invalid(last, err)
+		}
+		target.SetBool(value)
+
+	case "int":
+		value, err := strconv.Atoi(last)
+		if err != nil {
+			return invalid(last, err)
+		}
+		target.SetInt(int64(value))
+
+	case "list":
+		list := []string{}
+		if field.Appends() {
+			list = append(list, target.Interface().([]string)...)
+		}
+		for _, value := range values {
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					list = append(list, item)
+				}
+			}
+		}
+		target.Set(reflect.ValueOf(list))
+
+	default:
+		if field.kind.Kind() != reflect.Slice {
+			value := reflect.New(field.kind)
+			if err := yaml.Unmarshal([]byte(last), value.Interface()); err != nil {
+				return invalid(last, err)
+			}
+			target.Set(value.Elem())
+			return nil
+		}
+
+		// Each value is either a list of items, or a single item
+		list := reflect.MakeSlice(field.kind, 0, len(values))
+		for _, value := range values {
+			var node yaml.Node
+			if err := yaml.Unmarshal([]byte(value), &node); err != nil {
+				return invalid(value, err)
+			}
+
+			if len(node.Content) > 0 && node.Content[0].Kind == yaml.SequenceNode {
+				items := reflect.New(field.kind)
+				if err := node.Decode(items.Interface()); err != nil {
+					return invalid(value, err)
+				}
+				list = reflect.AppendSlice(list, items.Elem())
+				continue
+			}
+
+			item := reflect.New(field.kind.Elem())
+			if err := node.Decode(item.Interface()); err != nil {
+				return invalid(value, err)
+			}
+			list = reflect.Append(list, item.Elem())
+		}
+		target.Set(list)
+	}
+
+	return nil
+}
+
+// applyEnv overrides the settings of the configuration with the environment variables that are set
+func applyEnv(config *AuteurConfig) error {
+	for _, field := range ConfigFields() {
+		for _, env := range []string{legacyEnv[field.Key], field.Env()} {
+			if env == "" {
+				continue
+			}
+
+			if value, ok := os.LookupEnv(env); ok && value != "" {
+				if err := field.Set(config, []string{value}); err != nil {
+					return fmt.Errorf("%s: %w", env, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyOverrides overrides the settings of the configuration with the given values, by key
+func applyOverrides(config *AuteurConfig, overrides map[string][]string) error {
+	for _, field := range ConfigFields() {
+		if values, ok := overrides[field.Key]; ok {
+			if err := field.Set(config, values); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigFields(t *testing.T) {
+	fields := map[string]ConfigField{}
+	for _, field := range ConfigFields() {
+		fields[field.Key] = field
+	}
+
+	t.Run("Every setting of the configuration file is listed", func(t *testing.T) {
+		for _, key := range []string{"title", "root", "exclude", "links", "highlight.dark", "repository.editURL", "versions"} {
+			assert.Contains(t, fields, key)
+		}
+		assert.NotContains(t, fields, "lang")
+	})
+
+	t.Run("The priority of the root page can't be overridden", func(t *testing.T) {
+		assert.NotContains(t, fields, "priority")
+	})
+
+	t.Run("Only exclude patterns are added to the current ones", func(t *testing.T) {
+		assert.True(t, fields["exclude"].Appends())
+		assert.False(t, fields["include"].Appends())
+	})
+
+	t.Run("Flags and environment variables are named after the key", func(t *testing.T) {
+		assert.Equal(t, "repository-edit-url", fields["repository.editURL"].Flag())
+		assert.Equal(t, "AUTEUR_REPOSITORY_EDITURL", fields["repository.editURL"].Env())
+		assert.Equal(t, "base-url", fields["baseURL"].Flag())
+	})
+
+	t.Run("Values are decoded according to the type of the setting", func(t *testing.T) {
+		config := AuteurConfig{Include: []string{"*.md"}}
+
+		assert.NoError(t, fields["workers"].Set(&config, []string{"4"}))
+		assert.NoError(t, fields["offline"].Set(&config, []string{"true"}))
+		assert.NoError(t, fields["include"].Set(&config, []string{"tmp,dist", "drafts"}))
+		assert.NoError(t, fields["links"].Set(&config, []string{`{title: GitHub, url: "https://github.com"}`, `[{"title": "Blog", "url": "/blog"}]`}))
+
+		assert.Equal(t, 4, config.Workers)
+		assert.True(t, config.Offline)
+		assert.Equal(t, []string{"tmp", "dist", "drafts"}, config.Include)
+		assert.Equal(t, []Link{{Title: "GitHub", Href: "https://github.com"}, {Title: "Blog", Href: "/blog"}}, config.Links)
+
+		assert.Error(t, fields["workers"].Set(&config, []string{"four"}))
+	})
+	t.Run("Exclude patterns are added to the current ones", func(t *testing.T) {
+		config := AuteurConfig{Exclude: []string{"node_modules"}}
+
+		assert.NoError(t, fields["exclude"].Set(&config, []string{"tmp,dist", "drafts"}))
+		assert.Equal(t, []string{"node_modules", "tmp", "dist", "drafts"}, config.Exclude)
+	})
+}
+
+func TestConfigPrecedence(t *testing.T) {
+	folder := t.TempDir()
+	file := filepath.Join(folder, "auteur.yaml")
+	os.WriteFile(file, []byte("title: File\ndesc: File\nversion: 1.0.0\nroot: docs\nsources:\n  - root: libs\n    mount: reference\n"), 0644)
+
+	t.Setenv("AUTEUR_DESC", "Env")
+	t.Setenv("AUTEUR_VERSION", "2.0.0")
+	t.Setenv("AUTEUR_EXCLUDE", "tmp")
+
+	config, err := LoadConfig(ConfigOptions{
+		File:      file,
+		Overrides: map[string][]string{"version": {"3.0.0"}, "exclude": {"drafts"}},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "File", config.Title)
+	assert.Equal(t, "Env", config.Desc)
+	assert.Equal(t, "3.0.0", config.Version)
+	assert.Equal(t, append(append([]string{}, DefaultExclude...), "tmp", "drafts"), config.Exclude)
+
+	t.Run("Paths of the configuration file are relative to its folder", func(t *testing.T) {
+		assert.Equal(t, filepath.Join(folder, "docs"), config.Rootdir)
+		assert.Equal(t, []SourceConfig{{Root: filepath.Join(folder, "libs"), Mount: "reference"}}, config.SourceFolders)
+	})
+
+	t.Run("A missing configuration file is an error", func(t *testing.T) {
+		_, err := LoadConfig(ConfigOptions{File: filepath.Join(folder, "missing.yaml")})
+		assert.Error(t, err)
+	})
+}
@@ -16,6 +16,21 @@ type Processor interface {
 	Load(site *Auteur, file string) ([]Content, error)
 }
 
+// DependentProcessor can be implemented by processors whose output for a file
+// also depends on other files (e.g every file of a Go package).
+// Incremental builds process the file again whenever one of them changes
+type DependentProcessor interface {
+	Dependencies(site *Auteur, file string) []string
+}
+
+// Origin is the location of a chunk of content in its source file.
+// Lines start at 1, and are left at 0 when the content maps to the whole file
+type Origin struct {
+	File      string `json:"file" yaml:"file"`
+	StartLine int    `json:"start" yaml:"start"`
+	EndLine   int    `json:"end" yaml:"end"`
+}
+
 type Content interface {
 	Type() ContentType
 	Data() string
@@ -23,5 +38,9 @@ type Content interface {
 	Title() string
 	Meta() Metadata
 	Priority() int
+	// Locale the content is written in, empty for the default locale
+	Locale() string
+	// Location of the content in its source file
+	Origin() Origin
 	Len() int
 }
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/patrixr/auteur/common"
+)
+
+// FolderConfig is read from the configuration file of a subfolder of the root folder.
+// Its settings apply to the section of the folder and to every page below it
+type FolderConfig struct {
+	// Title of the section of the folder
+	Title string `yaml:"title" json:"title" toml:"title"`
+	// Priority of the section of the folder among its siblings
+	Priority *int `yaml:"priority" json:"priority" toml:"priority"`
+	// Patterns excluded from the folder, in addition to the ones of its parent folders
+	Exclude []string `yaml:"exclude" json:"exclude" toml:"exclude"`
+	// Links shown on the pages of the folder, in addition to the ones of its parent folders
+	Links []Link `yaml:"links" json:"links" toml:"links"`
+	// Web Awesome theme of the pages of the folder
+	Theme string `yaml:"theme" json:"theme" toml:"theme"`
+}
+
+// folderScope is the configuration of a subfolder, found while collecting files
+type folderScope struct {
+	file   string
+	config FolderConfig
+	// Href of the section of the folder, before the configuration is applied
+	href string
+}
+
+// readScope records the configuration file of a subfolder of a source, if it has one
+func (site *Auteur) readScope(root *sourceRoot, folder string) error {
+	file := findConfigFile(folder)
+	if file == "" {
+		return nil
+	}
+
+	common.Log("Folder configuration detected", "file", file)
+
+	rel, err := filepath.Rel(root.root, folder)
+	if err != nil {
+		return err
+	}
+
+	scope := folderScope{
+		file: file,
+		href: hrefOfPath(append(slices.Clip(root.mount), strings.Split(filepath.ToSlash(rel), "/")...)),
+	}
+
+	if err := readConfigFile(file, &scope.config); err != nil {
+		return err
+	}
+
+	root.exclusion.AddRules(folder, scope.config.Exclude)
+	site.scopes = append(site.scopes, scope)
+	return nil
+}
+
+// Excluded returns true if the file or folder is left out of the site, see Exclusion
+func (site *Auteur) Excluded(path string, isDir bool) bool {
+	if root := site.rootOf(path); root != nil {
+		return root.exclusion.ExcludedPath(path, isDir)
+	}
+
+	return NewExclusion(site.Rootdir, site.Exclude, site.Include, site.IgnoreFiles).ExcludedPath(path, isDir)
+}
+
+// applyScopes applies the configuration files of the subfolders to their section of the page tree.
+// Sections are found before any of them is renamed, since titles change the hrefs of their subtree
+func (site *Auteur) applyScopes() {
+	sections := make([]*Auteur, len(site.scopes))
+
+	for i, scope := range site.scopes {
+		sections[i] = site.FindPage(scope.href)
+	}
+
+	// Scopes are collected parents first, nested folders override their parents
+	for i, scope := range site.scopes {
+		section := sections[i]
+
+		if section == nil || section.IsRoot() {
+			common.LogWarn("Folder configuration doesn't match any page", "file", scope.file)
+			continue
+		}
+
+		section.applyScope(scope.config, true)
+
+		if scope.config.Priority != nil {
+			slices.SortStableFunc(section.parent.children, func(a, b *Auteur) int {
+				return b.Priority - a.Priority
+			})
+		}
+	}
+}
+
+// applyScope merges the configuration of a folder onto the page and its children,
+// the title and priority only apply to the section of the folder
+func (site *Auteur) applyScope(config FolderConfig, section bool) {
+	other := AuteurConfig{
+		Theme:    config.Theme,
+		Links:    config.Links,
+		Exclude:  config.Exclude,
+		Priority: site.Priority,
+	}
+
+	if section {
+		other.Title = config.Title
+		if config.Priority != nil {
+			other.Priority = *config.Priority
+		}
+	}
+
+	site.AuteurConfig = site.ExtendConfig(&other)
+
+	for _, child := range site.children {
+		child.applyScope(config, false)
+	}
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FolderProcessor places the content of each file on the page of its path relative to its source folder
+type FolderProcessor struct{}
+
+func (p FolderProcessor) Supports(ext string) bool {
+	return ext == ".txt"
+}
+
+func (p FolderProcessor) Load(site *Auteur, file string) ([]Content, error) {
+	rel, err := site.SourcePath(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return []Content{&CachedContent{
+		Kind:     HTML,
+		Body:     filepath.Base(file),
+		Segments: strings.Split(strings.TrimSuffix(filepath.ToSlash(rel), ".txt"), "/"),
+	}}, nil
+}
+
+func TestFolderConfig(t *testing.T) {
+	rootdir := t.TempDir()
+
+	files := map[string]string{
+		"intro.txt":                  "",
+		"reference/api.txt":          "",
+		"guides/setup.txt":           "",
+		"guides/drafts/wip.txt":      "",
+		"guides/advanced/tuning.txt": "",
+		"guides/auteur.yaml": `
+title: Tutorials
+priority: 10
+exclude: [drafts]
+theme: awesome
+links:
+  - title: Forum
+    url: https://forum.example.com
+`,
+		"guides/advanced/auteur.json": `{"theme": "shoelace", "links": [{"title": "Benchmarks", "url": "/benchmarks"}]}`,
+	}
+
+	for file, content := range files {
+		os.MkdirAll(filepath.Join(rootdir, filepath.Dir(file)), 0755)
+		os.WriteFile(filepath.Join(rootdir, file), []byte(content), 0644)
+	}
+
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+	site.Rootdir = rootdir
+	site.Theme = "default"
+	site.Links = []Link{{Title: "GitHub", Href: "https://github.com"}}
+	site.RegisterProcessor(FolderProcessor{})
+
+	assert.NoError(t, site.Ingest(rootdir))
+
+	t.Run("The section is renamed and reordered", func(t *testing.T) {
+		assert.Nil(t, site.FindPage("/guides"))
+
+		section := site.FindPage("/tutorials")
+		assert.NotNil(t, section)
+		assert.Equal(t, 10, section.Priority)
+		assert.Equal(t, section, site.Children()[0])
+	})
+
+	t.Run("Excludes only apply to the folder", func(t *testing.T) {
+		assert.Nil(t, site.FindPage("/tutorials/drafts"))
+		assert.True(t, site.Excluded(filepath.Join(rootdir, "guides", "advanced", "drafts"), true))
+		assert.False(t, site.Excluded(filepath.Join(rootdir, "reference", "drafts"), true))
+	})
+
+	t.Run("Settings apply to the pages of the section", func(t *testing.T) {
+		setup := site.FindPage("/tutorials/setup")
+		assert.NotNil(t, setup)
+		assert.Equal(t, "awesome", setup.Theme)
+		assert.Equal(t, []Link{site.Links[0], {Title: "Forum", Href: "https://forum.example.com"}}, setup.Links)
+	})
+
+	t.Run("Nested folders extend their parents", func(t *testing.T) {
+		tuning := site.FindPage("/tutorials/advanced/tuning")
+		assert.NotNil(t, tuning)
+		assert.Equal(t, "shoelace", tuning.Theme)
+		assert.Equal(t, []string{"GitHub", "Forum", "Benchmarks"}, []string{tuning.Links[0].Title, tuning.Links[1].Title, tuning.Links[2].Title})
+	})
+
+	t.Run("Other sections keep the site settings", func(t *testing.T) {
+		api := site.FindPage("/reference/api")
+		assert.NotNil(t, api)
+		assert.Equal(t, "default", api.Theme)
+		assert.Equal(t, site.Links, api.Links)
+		assert.Equal(t, "default", site.Theme)
+		assert.Len(t, site.Links, 1)
+	})
+
+	t.Run("Invalid folder configuration fails the ingestion", func(t *testing.T) {
+		os.WriteFile(filepath.Join(rootdir, "reference", "auteur.yml"), []byte("priority: high"), 0644)
+		defer os.Remove(filepath.Join(rootdir, "reference", "auteur.yml"))
+
+		site, err := NewAuteur()
+		assert.NoError(t, err)
+		site.Rootdir = rootdir
+		site.RegisterProcessor(FolderProcessor{})
+
+		err = site.Ingest(rootdir)
+		assert.ErrorContains(t, err, filepath.Join(rootdir, "reference", "auteur.yml")+":1")
+	})
+}
@@ -0,0 +1,156 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// sourceRoot is a folder being ingested, along with the processors and exclusion rules of its files
+type sourceRoot struct {
+	root       string
+	mount      []string
+	exclude    []string
+	processors []Processor
+	exclusion  *Exclusion
+}
+
+// mountedContent places the content of a source under its mount path
+type mountedContent struct {
+	Content
+	mount []string
+}
+
+func (c mountedContent) Path() []string {
+	return append(slices.Clip(c.mount), c.Content.Path()...)
+}
+
+// RegisterSourceProcessor registers a processor for the files of one of the sources of the configuration,
+// given by its index. Sources with processors of their own don't use the processors of the site
+func (site *Auteur) RegisterSourceProcessor(source int, processor Processor) {
+	if site.sourceProcessors == nil {
+		site.sourceProcessors = map[int][]Processor{}
+	}
+	site.sourceProcessors[source] = append(site.sourceProcessors[source], processor)
+}
+
+// resolveRoots returns the folders to ingest: the sources of the configuration,
+// or the given folder when there are none
+func (site *Auteur) resolveRoots(infolder string) ([]*sourceRoot, error) {
+	if len(site.SourceFolders) == 0 {
+		root, err := filepath.Abs(infolder)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*sourceRoot{{
+			root:       root,
+			processors: site.processors,
+			exclusion:  NewExclusion(root, site.Exclude, site.Include, site.IgnoreFiles),
+		}}, nil
+	}
+
+	roots := []*sourceRoot{}
+
+	for i, source := range site.SourceFolders {
+		root, err := filepath.Abs(source.Root)
+		if err != nil {
+			return nil, err
+		}
+
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("source %s isn't a folder", source.Root)
+		}
+
+		processors := site.processors
+		if registered, ok := site.sourceProcessors[i]; ok {
+			processors = registered
+		}
+
+		include := site.Include
+		if len(source.Include) > 0 {
+			include = source.Include
+		}
+
+		mount := []string{}
+		for _, part := range strings.Split(source.Mount, "/") {
+			if strings.TrimSpace(part) != "" {
+				mount = append(mount, part)
+			}
+		}
+
+		roots = append(roots, &sourceRoot{
+			root:       root,
+			mount:      mount,
+			exclude:    source.Exclude,
+			processors: processors,
+			exclusion:  NewExclusion(root, append(slices.Clip(site.Exclude), source.Exclude...), include, site.IgnoreFiles),
+		})
+	}
+
+	return roots, nil
+}
+
+// rootOf returns the innermost source folder containing the file, or nil if it isn't part of any
+func (site *Auteur) rootOf(file string) *sourceRoot {
+	var found *sourceRoot
+
+	for _, root := range site.Root().roots {
+		if file != root.root && !strings.HasPrefix(file, root.root+string(filepath.Separator)) {
+			continue
+		}
+
+		if found == nil || len(root.root) > len(found.root) {
+			found = root
+		}
+	}
+
+	return found
+}
+
+// processorsOf returns the processors used to ingest the file
+func (site *Auteur) processorsOf(file string) []Processor {
+	if root := site.rootOf(file); root != nil {
+		return root.processors
+	}
+	return site.Root().processors
+}
+
+// mountContents places the content loaded from the file under the mount path of its source
+func (site *Auteur) mountContents(file string, contents []Content) []Content {
+	root := site.rootOf(file)
+	if root == nil || len(root.mount) == 0 {
+		return contents
+	}
+
+	mounted := make([]Content, len(contents))
+	for i, content := range contents {
+		if content != nil {
+			mounted[i] = mountedContent{Content: content, mount: root.mount}
+		}
+	}
+
+	return mounted
+}
+
+// SourcePath returns the path of the file relative to the folder of the source it is part of,
+// processors infer the page of the file from it. It is relative to the root folder when there are no sources
+func (site *Auteur) SourcePath(file string) (string, error) {
+	root := site.rootOf(file)
+	if root == nil {
+		return site.GetRelativePath(file)
+	}
+
+	rel, err := filepath.Rel(root.root, file)
+	if err != nil {
+		return "", fmt.Errorf("failed to get relative path: %w", err)
+	}
+
+	if rel == "." {
+		return "", nil
+	}
+
+	return rel, nil
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSources(t *testing.T) {
+	rootdir := t.TempDir()
+
+	files := []string{
+		"README.txt",
+		"docs/intro.txt",
+		"docs/guides/setup.txt",
+		"services/api/notes.txt",
+		"services/api/internal/secret.txt",
+		"services/web/notes.txt",
+		"libs/auth.md",
+		"libs/notes.txt",
+	}
+
+	for _, file := range files {
+		os.MkdirAll(filepath.Join(rootdir, filepath.Dir(file)), 0755)
+		os.WriteFile(filepath.Join(rootdir, file), []byte(file), 0644)
+	}
+
+	newSite := func() *Auteur {
+		site, err := NewAuteur()
+		assert.NoError(t, err)
+		site.Rootdir = rootdir
+		site.SourceFolders = []SourceConfig{
+			{Root: filepath.Join(rootdir, "docs")},
+			{Root: filepath.Join(rootdir, "services"), Mount: "services", Exclude: []string{"internal", "*.bak"}},
+			{Root: filepath.Join(rootdir, "libs"), Mount: "reference/libs"},
+		}
+		site.RegisterProcessor(FolderProcessor{})
+		site.RegisterSourceProcessor(2, MockProcessor{
+			supportedExt: ".md",
+			contents:     []Content{&CachedContent{Kind: HTML, Body: "auth", Segments: []string{"auth"}}},
+		})
+		return site
+	}
+
+	site := newSite()
+	assert.NoError(t, site.Ingest(rootdir))
+
+	t.Run("Sources without a mount path are placed at the root of the site", func(t *testing.T) {
+		assert.NotNil(t, site.FindPage("/intro"))
+		assert.NotNil(t, site.FindPage("/guides/setup"))
+		assert.Nil(t, site.FindPage("/docs"))
+	})
+
+	t.Run("The root folder is only ingested through the sources", func(t *testing.T) {
+		assert.Nil(t, site.FindPage("/readme"))
+	})
+
+	t.Run("Sources are placed under their mount path", func(t *testing.T) {
+		assert.NotNil(t, site.FindPage("/services/api/notes"))
+		assert.NotNil(t, site.FindPage("/services/web/notes"))
+		assert.NotNil(t, site.FindPage("/reference/libs/auth"))
+	})
+
+	t.Run("Sources have their own exclude patterns", func(t *testing.T) {
+		assert.Nil(t, site.FindPage("/services/api/internal"))
+		assert.True(t, site.Excluded(filepath.Join(rootdir, "services", "api", "internal"), true))
+		assert.False(t, site.Excluded(filepath.Join(rootdir, "docs", "internal"), true))
+	})
+
+	t.Run("Sources have their own processors", func(t *testing.T) {
+		assert.Nil(t, site.FindPage("/reference/libs/notes"))
+		assert.Nil(t, site.FindPage("/auth"))
+	})
+
+	t.Run("Paths are relative to the folder of their source", func(t *testing.T) {
+		file := filepath.Join(rootdir, "docs", "guides", "setup.txt")
+
+		path, err := site.SourcePath(file)
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join("guides", "setup.txt"), path)
+
+		path, err = site.GetRelativePath(file)
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join("docs", "guides", "setup.txt"), path)
+	})
+
+	t.Run("Exclude patterns of the sources are checked", func(t *testing.T) {
+		problems, err := newSite().Check(rootdir)
+		assert.NoError(t, err)
+
+		messages := []string{}
+		for _, problem := range problems {
+			if problem.Rule == "exclude" {
+				messages = append(messages, problem.Message)
+			}
+		}
+
+		assert.Len(t, messages, 1)
+		assert.True(t, strings.Contains(messages[0], "*.bak"))
+	})
+
+	t.Run("Sources are linted by their own processors", func(t *testing.T) {
+		os.WriteFile(filepath.Join(rootdir, "libs", "broken.txt"), []byte("broken"), 0644)
+		defer os.Remove(filepath.Join(rootdir, "libs", "broken.txt"))
+
+		site := newSite()
+		site.RegisterSourceProcessor(2, LintingMockProcessor{
+			MockProcessor: MockProcessor{supportedExt: ".txt"},
+			problems:      []Problem{{Severity: SeverityError, Rule: "frontmatter", File: filepath.Join(rootdir, "libs", "broken.txt"), Line: 1}},
+		})
+
+		problems, err := site.Check(rootdir)
+		assert.NoError(t, err)
+		assert.Contains(t, problems, Problem{Severity: SeverityError, Rule: "frontmatter", File: "libs/broken.txt", Line: 1})
+	})
+
+	t.Run("Missing source folders fail the ingestion", func(t *testing.T) {
+		site := newSite()
+		site.SourceFolders = append(site.SourceFolders, SourceConfig{Root: filepath.Join(rootdir, "missing")})
+		assert.ErrorContains(t, site.Ingest(rootdir), "missing")
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"unicode"
 
 	. "github.com/patrixr/auteur/common"
 	. "github.com/patrixr/auteur/core"
@@ -14,6 +15,9 @@ import (
 
 const AUTEUR_TAG = "@auteur"
 
+// Arguments of the @auteur(...) tag, a comma separated list of quoted strings
+const auteurArgsPattern = `\s*((?:("[^"\n]*")\s*,\s*)*(?:("[^"\n]*")))?\s*`
+
 type CommentStyle struct {
 	BlockStart  string
 	BlockEnd    string
@@ -183,26 +187,26 @@ func (r *CommentProcessor) Load(auteur *Auteur, file string) ([]Content, error)
 
 	out := []Content{}
 
-	comments := findCommentsInText(string(content), style)
+	comments := findCommentBlocks(string(content), style)
 
-	relPath, err := auteur.GetRelativePath(file)
+	relPath, err := auteur.SourcePath(file)
 	folderPath := filepath.Dir(relPath)
 	// Default to the path of the file the content is contained in
 	path := strings.Split(folderPath, "/")
 
 	for _, comment := range comments {
-		include, args, trimmed := extractAuteurMetaFromComment(comment)
+		include, args, trimmed := extractAuteurMetaFromComment(comment.Text)
 
 		if !include {
 			continue
 		}
 
-		meta, html, err := MarkdownToHTMLWithMeta([]byte(trimmed))
+		meta, html, err := MarkdownFileToHTMLWithMeta([]byte(trimmed), file)
 		if err != nil {
 			return out, err
 		}
 
-		fm, err := MetaToStruct[AuteurFrontmatter](meta)
+		fm, meta, err := parseFrontmatter(auteur, meta, file, comment.Text, comment.StartLine)
 		if err != nil {
 			return out, err
 		}
@@ -225,12 +229,50 @@ func (r *CommentProcessor) Load(auteur *Auteur, file string) ([]Content, error)
 			kind:     HTML,
 			title:    fm.Title,
 			priority: fm.Priority,
+			locale:   contentLocale(auteur, file, fm),
+			origin:   Origin{File: file, StartLine: comment.StartLine, EndLine: comment.EndLine},
 		})
 	}
 
 	return out, nil
 }
 
+// Lint Reports the malformed @auteur tags of the file, and the frontmatter problems of its comments
+func (r *CommentProcessor) Lint(site *Auteur, file string) []Problem {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return []Problem{{Severity: SeverityError, Rule: "load", File: file, Message: err.Error()}}
+	}
+
+	problems := []Problem{}
+
+	for _, comment := range findCommentBlocks(string(content), getCommentStyle(file)) {
+		problems = append(problems, lintAuteurTags(file, comment)...)
+
+		include, _, trimmed := extractAuteurMetaFromComment(comment.Text)
+		if !include {
+			continue
+		}
+
+		meta, _, err := MarkdownFileToHTMLWithMeta([]byte(trimmed), file)
+		if err != nil {
+			problems = append(problems, Problem{
+				Severity: SeverityError,
+				Rule:     "frontmatter",
+				File:     file,
+				Line:     comment.StartLine,
+				Message:  fmt.Sprintf("Invalid frontmatter: %s", err),
+			})
+			continue
+		}
+
+		_, _, found := ParseFrontmatter(site, meta, file, comment.Text, comment.StartLine)
+		problems = append(problems, found...)
+	}
+
+	return problems
+}
+
 // -----------------------------------
 // Helpers
 // -----------------------------------
@@ -248,8 +290,7 @@ func getCommentStyle(filename string) CommentStyle {
 // tag inside of it. If yes, returns true, alongside the arguments of the tag
 // and the text trimmed of said tag
 func extractAuteurMetaFromComment(text string) (present bool, args []string, trimmed string) {
-	argsPattern := `\s*((?:("[^"\n]*")\s*,\s*)*(?:("[^"\n]*")))?\s*`
-	auteurPattern := fmt.Sprintf(`%s(?:[\s\n]|$)|%s\(%s\)`, AUTEUR_TAG, AUTEUR_TAG, argsPattern)
+	auteurPattern := fmt.Sprintf(`%s(?:[\s\n]|$)|%s\(%s\)`, AUTEUR_TAG, AUTEUR_TAG, auteurArgsPattern)
 	auteurRexp := regexp.MustCompile(auteurPattern)
 	auteurMatches := auteurRexp.FindAllStringSubmatch(text, -1)
 
@@ -275,27 +316,63 @@ func extractAuteurMetaFromComment(text string) (present bool, args []string, tri
 	return
 }
 
+// commentBlock is a comment found in a code file, along with the lines it spans
+type commentBlock struct {
+	Text      string
+	StartLine int
+	EndLine   int
+}
+
 // Given a code file, finds all the comment blocks present inside ot it
 // Comment symbols (//) are trimmed before returning the text content
 func findCommentsInText(text string, style CommentStyle) []string {
 	comments := []string{}
 
+	for _, block := range findCommentBlocks(text, style) {
+		comments = append(comments, block.Text)
+	}
+
+	return comments
+}
+
+// findCommentBlocks finds all the comment blocks of a code file, with their position
+func findCommentBlocks(text string, style CommentStyle) []commentBlock {
+	comments := []commentBlock{}
+
+	lineAt := func(offset int) int {
+		return strings.Count(text[:offset], "\n") + 1
+	}
+
 	// Block comments
 	pattern := fmt.Sprintf(`(?s)%s(.*?)%s`, style.BlockStart, style.BlockEnd)
-	matches := regexp.MustCompile(pattern).FindAllStringSubmatch(text, -1)
+	matches := regexp.MustCompile(pattern).FindAllStringSubmatchIndex(text, -1)
 
 	for _, match := range matches {
-		comments = append(comments, match[1])
+		comments = append(comments, commentBlock{
+			Text:      text[match[2]:match[3]],
+			StartLine: lineAt(match[0]),
+			EndLine:   lineAt(match[1]),
+		})
 	}
 
 	// Line comments
 	for _, comment := range style.LineComment {
 		pattern = fmt.Sprintf(`(?m)(^\s*%s[^\n]*\n)+`, comment)
-		blocks := regexp.MustCompile(pattern).FindAllString(text, -1)
-		for _, block := range blocks {
+		blocks := regexp.MustCompile(pattern).FindAllStringIndex(text, -1)
+		for _, loc := range blocks {
+			block := text[loc[0]:loc[1]]
 			trimPattern := fmt.Sprintf(`(?m)^\s*%s`, comment)
 			trimmed := regexp.MustCompile(trimPattern).ReplaceAllString(block, "")
-			comments = append(comments, trimmed)
+
+			// \s also matches the blank lines preceding the comment
+			start := loc[0] + len(block) - len(strings.TrimLeftFunc(block, unicode.IsSpace))
+
+			comments = append(comments, commentBlock{
+				Text:      trimmed,
+				StartLine: lineAt(start),
+				// The block ends with the line break of its last line
+				EndLine: lineAt(loc[1] - 1),
+			})
 		}
 	}
 
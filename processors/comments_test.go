@@ -223,7 +223,7 @@ func TestAuteurCommentParsing(t *testing.T) {
 				# World
 			`),
 			ext:      ".py",
-			expected: "<h1>Heading 1</h1>\n<p>Hello</p>\n<h2>Heading 2</h2>\n<p>World</p>\n",
+			expected: "<h1 id=\"heading-1\">Heading 1</h1>\n<p>Hello</p>\n<h2 id=\"heading-2\">Heading 2</h2>\n<p>World</p>\n",
 		},
 		{
 			name: "Python style comment with triple quotes",
@@ -277,3 +277,23 @@ func TestAuteurCommentParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestCommentOrigins(t *testing.T) {
+	rootdir := t.TempDir()
+	file := filepath.Join(rootdir, "main.go")
+
+	source := "package main\n\n// @auteur(\"guide\")\n// # First\nfunc first() {}\n\n/*\n@auteur(\"guide\")\n# Second\n*/\nfunc second() {}\n"
+	os.WriteFile(file, []byte(source), 0644)
+
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+	site.Rootdir = rootdir
+
+	contents, err := NewCommentReader().Load(site, file)
+	assert.NoError(t, err)
+	assert.Len(t, contents, 2)
+
+	origins := []Origin{contents[0].Origin(), contents[1].Origin()}
+	assert.Contains(t, origins, Origin{File: file, StartLine: 3, EndLine: 4})
+	assert.Contains(t, origins, Origin{File: file, StartLine: 7, EndLine: 10})
+}
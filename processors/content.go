@@ -1,6 +1,8 @@
 package processors
 
 import (
+	"bytes"
+
 	. "github.com/patrixr/auteur/common"
 	. "github.com/patrixr/auteur/core"
 )
@@ -14,6 +16,8 @@ type ContentData struct {
 	metadata Metadata
 	title    string
 	priority int
+	locale   string
+	origin   Origin
 }
 
 func (c *ContentData) Type() ContentType {
@@ -24,6 +28,14 @@ func (c *ContentData) Priority() int {
 	return c.priority
 }
 
+func (c *ContentData) Locale() string {
+	return c.locale
+}
+
+func (c *ContentData) Origin() Origin {
+	return c.origin
+}
+
 func (c *ContentData) Data() string {
 	return c.data
 }
@@ -43,3 +55,12 @@ func (c *ContentData) Title() string {
 func (c *ContentData) Len() int {
 	return len(c.data)
 }
+
+// lineCount returns the number of lines of a file's content
+func lineCount(content []byte) int {
+	lines := bytes.Count(content, []byte("\n"))
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		lines += 1
+	}
+	return lines
+}
@@ -0,0 +1,220 @@
+package processors
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+)
+
+type AuteurFrontmatter struct {
+	Path     string `yaml:"path"`
+	Title    string `yaml:"title"`
+	Priority int    `yaml:"priority"`
+	Ignore   bool   `yaml:"ignore"`
+	Lang     string `yaml:"lang"`
+}
+
+type FieldType string
+
+const (
+	StringField FieldType = "string"
+	IntField    FieldType = "integer"
+	BoolField   FieldType = "boolean"
+	DateField   FieldType = "date"
+)
+
+// FrontmatterField declares a key of the frontmatter
+type FrontmatterField struct {
+	Name string
+	Type FieldType
+	// Other names of the key, renamed to Name when the frontmatter is parsed
+	Aliases []string
+	// Values the key accepts, any value of the right type is accepted when it returns none
+	Values func(site *Auteur) []string
+}
+
+// FrontmatterSchema declares every frontmatter key Auteur reads.
+// Keys are matched regardless of their case
+var FrontmatterSchema = []FrontmatterField{
+	{Name: "title", Type: StringField},
+	{Name: "path", Type: StringField, Aliases: []string{"auteur"}},
+	{Name: "priority", Type: IntField, Aliases: []string{"order"}},
+	{Name: "ignore", Type: BoolField},
+	{Name: "lang", Type: StringField, Values: func(site *Auteur) []string {
+		return site.Locales
+	}},
+	{Name: "date", Type: DateField},
+}
+
+// ParseFrontmatter validates the metadata of a file against the frontmatter schema.
+// Aliased keys are renamed to the key they stand for in the returned metadata.
+// Text is the source the metadata was read from, starting at the given line of the file,
+// it is used to report problems on the line of their key
+func ParseFrontmatter(site *Auteur, meta Metadata, file string, text string, line int) (AuteurFrontmatter, Metadata, []Problem) {
+	parsed := Metadata{}
+	problems := []Problem{}
+
+	report := func(severity Severity, key string, format string, args ...interface{}) {
+		problems = append(problems, Problem{
+			Severity: severity,
+			Rule:     "frontmatter",
+			File:     file,
+			Line:     keyLine(text, line, key),
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	// Canonical keys first, so that they take precedence over their aliases
+	canonical := func(key string) int {
+		if field := fieldOf(key); field != nil && strings.EqualFold(field.Name, key) {
+			return 0
+		}
+		return 1
+	}
+
+	keys := make([]string, 0, len(meta))
+	for key := range meta {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, func(a, b string) int {
+		if order := canonical(a) - canonical(b); order != 0 {
+			return order
+		}
+		return strings.Compare(a, b)
+	})
+
+	for _, key := range keys {
+		value := meta[key]
+		field := fieldOf(key)
+
+		if field == nil {
+			severity := SeverityWarning
+			if site.Frontmatter.Strict {
+				severity = SeverityError
+			}
+
+			if !slices.ContainsFunc(site.Frontmatter.Keys, func(allowed string) bool { return strings.EqualFold(allowed, key) }) {
+				report(severity, key, "Unknown frontmatter key %q", key)
+			}
+
+			parsed[key] = value
+			continue
+		}
+
+		if _, ok := parsed[field.Name]; ok {
+			report(SeverityWarning, key, "Frontmatter key %q is ignored, %q is already set", key, field.Name)
+			continue
+		}
+
+		value, err := field.decode(value)
+		if err != nil {
+			report(SeverityError, key, "Invalid frontmatter key %q: %s", key, err)
+			continue
+		}
+
+		if field.Values != nil {
+			if allowed := field.Values(site); len(allowed) > 0 && !slices.ContainsFunc(allowed, func(a string) bool { return strings.EqualFold(a, fmt.Sprint(value)) }) {
+				report(SeverityError, key, "Invalid frontmatter key %q: %q isn't one of %s", key, value, strings.Join(allowed, ", "))
+				continue
+			}
+		}
+
+		parsed[field.Name] = value
+	}
+
+	fm, err := MetaToStruct[AuteurFrontmatter](parsed)
+	if err != nil {
+		report(SeverityError, "", "Invalid frontmatter: %s", err)
+	}
+
+	return fm, parsed, problems
+}
+
+// parseFrontmatter parses the frontmatter of content being loaded.
+// Warnings are logged, the first error is returned
+func parseFrontmatter(site *Auteur, meta Metadata, file string, text string, line int) (AuteurFrontmatter, Metadata, error) {
+	fm, parsed, problems := ParseFrontmatter(site, meta, file, text, line)
+
+	for _, problem := range problems {
+		if problem.Severity == SeverityError {
+			return fm, parsed, fmt.Errorf("%s:%d: %s", problem.File, problem.Line, problem.Message)
+		}
+		LogWarn(problem.Message, "file", problem.File, "line", problem.Line)
+	}
+
+	return fm, parsed, nil
+}
+
+// fieldOf returns the field of the schema declaring the key, or one of its aliases
+func fieldOf(key string) *FrontmatterField {
+	for i, field := range FrontmatterSchema {
+		if strings.EqualFold(field.Name, key) || slices.ContainsFunc(field.Aliases, func(alias string) bool { return strings.EqualFold(alias, key) }) {
+			return &FrontmatterSchema[i]
+		}
+	}
+	return nil
+}
+
+// decode returns the value converted to the type of the field
+func (field FrontmatterField) decode(value interface{}) (interface{}, error) {
+	switch field.Type {
+	case StringField:
+		if str, ok := value.(string); ok {
+			return str, nil
+		}
+	case IntField:
+		switch number := value.(type) {
+		case int:
+			return number, nil
+		case int64:
+			return int(number), nil
+		case uint64:
+			return int(number), nil
+		case float64:
+			if number == math.Trunc(number) {
+				return int(number), nil
+			}
+		}
+	case BoolField:
+		if boolean, ok := value.(bool); ok {
+			return boolean, nil
+		}
+	case DateField:
+		if _, ok := ParseDate(value); ok {
+			return value, nil
+		}
+		return nil, fmt.Errorf("expected a date such as 2006-01-02, got %v", value)
+	}
+
+	return nil, fmt.Errorf("expected a value of type %s, got %v", field.Type, value)
+}
+
+// keyLine returns the line of the file the key is set on
+func keyLine(text string, line int, key string) int {
+	if key == "" {
+		return line
+	}
+
+	for i, current := range strings.Split(text, "\n") {
+		// Keys can be preceded by comment markers, e.g // or *
+		current = strings.TrimLeftFunc(current, func(r rune) bool {
+			return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+
+		if len(current) < len(key) || !strings.EqualFold(current[:len(key)], key) {
+			continue
+		}
+
+		if strings.HasPrefix(strings.TrimSpace(current[len(key):]), ":") {
+			return line + i
+		}
+	}
+
+	return line
+}
@@ -0,0 +1,92 @@
+package processors
+
+import (
+	"testing"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrontmatterSchema(t *testing.T) {
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+
+	parse := func(meta Metadata, text string) (AuteurFrontmatter, Metadata, []Problem) {
+		return ParseFrontmatter(site, meta, "guide.md", text, 1)
+	}
+
+	t.Run("Aliases are renamed to their key", func(t *testing.T) {
+		fm, meta, problems := parse(Metadata{"order": 2, "auteur": "/guides", "Title": "Guide"}, "")
+		assert.Empty(t, problems)
+		assert.Equal(t, AuteurFrontmatter{Path: "/guides", Title: "Guide", Priority: 2}, fm)
+		assert.Equal(t, Metadata{"priority": 2, "path": "/guides", "title": "Guide"}, meta)
+	})
+
+	t.Run("Keys take precedence over their aliases", func(t *testing.T) {
+		fm, _, problems := parse(Metadata{"order": 2, "priority": 5}, "---\norder: 2\npriority: 5\n---")
+		assert.Equal(t, 5, fm.Priority)
+		assert.Len(t, problems, 1)
+		assert.Equal(t, SeverityWarning, problems[0].Severity)
+		assert.Equal(t, 2, problems[0].Line)
+	})
+
+	t.Run("Mistyped keys are errors on their line", func(t *testing.T) {
+		_, _, problems := parse(Metadata{"title": "Guide", "priority": "high"}, "---\ntitle: Guide\npriority: high\n---")
+		assert.Equal(t, []Problem{{
+			Severity: SeverityError,
+			Rule:     "frontmatter",
+			File:     "guide.md",
+			Line:     3,
+			Message:  `Invalid frontmatter key "priority": expected a value of type integer, got high`,
+		}}, problems)
+	})
+
+	t.Run("Only documented aliases are renamed", func(t *testing.T) {
+		fm, meta, problems := parse(Metadata{"slug": "intro", "weight": 3}, "")
+		assert.Equal(t, AuteurFrontmatter{}, fm)
+		assert.Equal(t, Metadata{"slug": "intro", "weight": 3}, meta)
+		assert.Len(t, problems, 2)
+	})
+
+	t.Run("Dates are validated", func(t *testing.T) {
+		_, _, problems := parse(Metadata{"date": "2025-01-01"}, "")
+		assert.Empty(t, problems)
+
+		_, _, problems = parse(Metadata{"date": "yesterday"}, "")
+		assert.Len(t, problems, 1)
+	})
+
+	t.Run("Languages must be one of the locales", func(t *testing.T) {
+		site.Locales = []string{"en", "fr"}
+		defer func() { site.Locales = nil }()
+
+		fm, _, problems := parse(Metadata{"lang": "FR"}, "")
+		assert.Empty(t, problems)
+		assert.Equal(t, "FR", fm.Lang)
+
+		_, _, problems = parse(Metadata{"lang": "de"}, "")
+		assert.Len(t, problems, 1)
+		assert.Equal(t, SeverityError, problems[0].Severity)
+	})
+
+	t.Run("Unknown keys are errors in strict mode unless allowed", func(t *testing.T) {
+		site.Frontmatter = FrontmatterConfig{Strict: true, Keys: []string{"hero"}}
+		defer func() { site.Frontmatter = FrontmatterConfig{} }()
+
+		_, meta, problems := parse(Metadata{"hero": "banner.png", "sidebar": "left"}, "---\n// hero: banner.png\n// sidebar: left\n---")
+		assert.Equal(t, "banner.png", meta["hero"])
+		assert.Len(t, problems, 1)
+		assert.Equal(t, SeverityError, problems[0].Severity)
+		assert.Equal(t, 3, problems[0].Line)
+	})
+}
+
+func TestKeyLine(t *testing.T) {
+	text := "---\n// priority_old: 1\n * Priority : 2\ntitle: Guide\n---"
+
+	assert.Equal(t, 3, keyLine(text, 1, "priority"))
+	assert.Equal(t, 4, keyLine(text, 1, "title"))
+	assert.Equal(t, 1, keyLine(text, 1, "date"))
+	assert.Equal(t, 1, keyLine(text, 1, ""))
+}
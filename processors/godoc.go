@@ -0,0 +1,294 @@
+package processors
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+)
+
+// GoDocProcessor generates an API reference page for every Go package,
+// listing its exported constants, variables, functions, types and methods.
+// Pages are placed in the site tree by import path, under the API section
+type GoDocProcessor struct {
+	Section string
+}
+
+func NewGoDocProcessor() Processor {
+	return &GoDocProcessor{Section: "api"}
+}
+
+func (r *GoDocProcessor) Supports(extension string) bool {
+	return extension == ".go"
+}
+
+// Dependencies A package page depends on every file of the package
+func (r *GoDocProcessor) Dependencies(site *Auteur, file string) []string {
+	files := packageFiles(site, filepath.Dir(file))
+
+	if len(files) == 0 || files[0] != file {
+		return nil
+	}
+
+	return files[1:]
+}
+
+// Load Each package is documented once, when its first file
+// (in alphabetical order) is loaded. Other files return no content
+func (r *GoDocProcessor) Load(site *Auteur, file string) ([]Content, error) {
+	folder := filepath.Dir(file)
+	files := packageFiles(site, folder)
+
+	if len(files) == 0 || files[0] != file {
+		return []Content{}, nil
+	}
+
+	Logf("Reading Go package %s", folder)
+
+	fset := token.NewFileSet()
+	parsed := []*ast.File{}
+
+	for _, f := range files {
+		astFile, err := parser.ParseFile(fset, f, nil, parser.ParseComments)
+		if err != nil {
+			return []Content{}, err
+		}
+		parsed = append(parsed, astFile)
+	}
+
+	importPath := goImportPath(folder)
+
+	pkg, err := doc.NewFromFiles(fset, parsed, importPath)
+	if err != nil {
+		return []Content{}, err
+	}
+
+	// Main packages and packages without exports don't get a reference page
+	if pkg.Name == "main" || !hasExports(pkg) {
+		return []Content{}, nil
+	}
+
+	md, err := packageMarkdown(fset, pkg)
+	if err != nil {
+		return []Content{}, err
+	}
+
+	html, err := MarkdownToHTML(md)
+	if err != nil {
+		return []Content{}, err
+	}
+
+	path := append([]string{r.Section}, strings.Split(importPath, "/")...)
+
+	return []Content{
+		&ContentData{
+			metadata: Metadata{"package": pkg.Name, "import": importPath},
+			data:     html,
+			path:     path,
+			kind:     HTML,
+			title:    pkg.Name,
+			origin:   Origin{File: file},
+		},
+	}, nil
+}
+
+// -----------------------------------
+// Helpers
+// -----------------------------------
+
+// packageFiles returns the sorted, non-test and non-excluded Go files of a folder
+func packageFiles(site *Auteur, folder string) []string {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil
+	}
+
+	files := []string{}
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		if site.Excluded(filepath.Join(folder, name), false) {
+			continue
+		}
+
+		files = append(files, filepath.Join(folder, name))
+	}
+
+	sort.Strings(files)
+	return files
+}
+
+// goImportPath resolves the import path of a package folder
+// by looking up the closest go.mod file
+func goImportPath(folder string) string {
+	dir := folder
+
+	for {
+		if module := readModulePath(filepath.Join(dir, "go.mod")); module != "" {
+			rel, err := filepath.Rel(dir, folder)
+			if err != nil || rel == "." {
+				return module
+			}
+			return module + "/" + filepath.ToSlash(rel)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return filepath.Base(folder)
+		}
+		dir = parent
+	}
+}
+
+func readModulePath(gomod string) string {
+	file, err := os.Open(gomod)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if module, ok := strings.CutPrefix(line, "module "); ok {
+			return strings.Trim(strings.TrimSpace(module), `"`)
+		}
+	}
+
+	return ""
+}
+
+func hasExports(pkg *doc.Package) bool {
+	return len(pkg.Consts) > 0 || len(pkg.Vars) > 0 || len(pkg.Funcs) > 0 || len(pkg.Types) > 0
+}
+
+// packageMarkdown renders the documentation of a package as markdown
+func packageMarkdown(fset *token.FileSet, pkg *doc.Package) ([]byte, error) {
+	var sb bytes.Buffer
+
+	fmt.Fprintf(&sb, "# package %s\n\n", pkg.Name)
+	fmt.Fprintf(&sb, "```go\nimport \"%s\"\n```\n\n", pkg.ImportPath)
+	sb.Write(pkg.Markdown(pkg.Doc))
+	sb.WriteString("\n")
+
+	writeValues := func(title string, values []*doc.Value) error {
+		if len(values) == 0 {
+			return nil
+		}
+
+		fmt.Fprintf(&sb, "## %s\n\n", title)
+		for _, value := range values {
+			if err := writeDecl(&sb, fset, value.Decl); err != nil {
+				return err
+			}
+			sb.Write(pkg.Markdown(value.Doc))
+			sb.WriteString("\n")
+		}
+		return nil
+	}
+
+	writeFuncs := func(level string, funcs []*doc.Func) error {
+		for _, fn := range funcs {
+			name := fn.Name
+			if fn.Recv != "" {
+				name = fmt.Sprintf("(%s) %s", fn.Recv, fn.Name)
+			}
+
+			fmt.Fprintf(&sb, "%s func %s\n\n", level, name)
+			if err := writeDecl(&sb, fset, fn.Decl); err != nil {
+				return err
+			}
+			sb.Write(pkg.Markdown(fn.Doc))
+			sb.WriteString("\n")
+		}
+		return nil
+	}
+
+	if err := writeValues("Constants", pkg.Consts); err != nil {
+		return nil, err
+	}
+
+	if err := writeValues("Variables", pkg.Vars); err != nil {
+		return nil, err
+	}
+
+	if len(pkg.Funcs) > 0 {
+		sb.WriteString("## Functions\n\n")
+		if err := writeFuncs("###", pkg.Funcs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(pkg.Types) > 0 {
+		sb.WriteString("## Types\n\n")
+	}
+
+	for _, t := range pkg.Types {
+		fmt.Fprintf(&sb, "### type %s\n\n", t.Name)
+		if err := writeDecl(&sb, fset, t.Decl); err != nil {
+			return nil, err
+		}
+		sb.Write(pkg.Markdown(t.Doc))
+		sb.WriteString("\n")
+
+		for _, values := range [][]*doc.Value{t.Consts, t.Vars} {
+			for _, value := range values {
+				if err := writeDecl(&sb, fset, value.Decl); err != nil {
+					return nil, err
+				}
+				sb.Write(pkg.Markdown(value.Doc))
+				sb.WriteString("\n")
+			}
+		}
+
+		if err := writeFuncs("####", t.Funcs); err != nil {
+			return nil, err
+		}
+
+		if err := writeFuncs("####", t.Methods); err != nil {
+			return nil, err
+		}
+	}
+
+	return sb.Bytes(), nil
+}
+
+// writeDecl prints the signature of a declaration as a go code block, without function bodies
+func writeDecl(sb *bytes.Buffer, fset *token.FileSet, decl ast.Decl) error {
+	if fn, ok := decl.(*ast.FuncDecl); ok {
+		stripped := *fn
+		stripped.Body = nil
+		stripped.Doc = nil
+		decl = &stripped
+	}
+
+	if gen, ok := decl.(*ast.GenDecl); ok {
+		stripped := *gen
+		stripped.Doc = nil
+		decl = &stripped
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, decl); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(sb, "```go\n%s\n```\n\n", buf.String())
+	return nil
+}
@@ -0,0 +1,89 @@
+package processors
+
+import (
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	. "github.com/patrixr/auteur/core"
+	"github.com/patrixr/q"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoDocProcessor(t *testing.T) {
+	tmpdir := t.TempDir()
+	pkgdir := filepath.Join(tmpdir, "pkg", "shapes")
+	os.MkdirAll(pkgdir, 0755)
+
+	os.WriteFile(filepath.Join(tmpdir, "go.mod"), []byte("module example.com/demo\n\ngo 1.23\n"), 0644)
+
+	os.WriteFile(filepath.Join(pkgdir, "circle.go"), []byte(q.Paragraph(`
+		// Package shapes draws shapes
+		package shapes
+
+		// Circle is a round shape
+		type Circle struct {
+			Radius float64
+			secret int
+		}
+
+		// NewCircle creates a circle
+		func NewCircle(r float64) *Circle {
+			return &Circle{Radius: r}
+		}
+	`)), 0644)
+
+	os.WriteFile(filepath.Join(pkgdir, "draw.go"), []byte(q.Paragraph(`
+		package shapes
+
+		// Draw renders the circle
+		func (c *Circle) Draw() string {
+			return "o"
+		}
+
+		func hidden() {}
+	`)), 0644)
+
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+
+	processor := NewGoDocProcessor()
+
+	t.Run("Only the first file of a package produces content", func(t *testing.T) {
+		got, err := processor.Load(site, filepath.Join(pkgdir, "draw.go"))
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("Package reference is generated by import path", func(t *testing.T) {
+		got, err := processor.Load(site, filepath.Join(pkgdir, "circle.go"))
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+
+		content := got[0]
+		assert.Equal(t, []string{"api", "example.com", "demo", "pkg", "shapes"}, content.Path())
+		assert.Equal(t, "shapes", content.Title())
+		assert.Contains(t, content.Data(), "Package shapes draws shapes")
+		assert.Contains(t, content.Data(), "Circle is a round shape")
+		assert.Contains(t, textOf(content.Data()), "func NewCircle(r float64) *Circle")
+		assert.Contains(t, textOf(content.Data()), "func (c *Circle) Draw() string")
+		assert.Contains(t, content.Data(), "Draw renders the circle")
+		assert.NotContains(t, content.Data(), "secret")
+		assert.NotContains(t, content.Data(), "hidden")
+	})
+
+	t.Run("Package files are reported as dependencies", func(t *testing.T) {
+		dependent := processor.(DependentProcessor)
+		assert.Equal(t, []string{filepath.Join(pkgdir, "draw.go")}, dependent.Dependencies(site, filepath.Join(pkgdir, "circle.go")))
+		assert.Empty(t, dependent.Dependencies(site, filepath.Join(pkgdir, "draw.go")))
+	})
+}
+
+var tagRexp = regexp.MustCompile(`<[^>]+>`)
+
+// textOf strips the markup of highlighted code blocks
+func textOf(markup string) string {
+	return html.UnescapeString(tagRexp.ReplaceAllString(markup, ""))
+}
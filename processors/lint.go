@@ -0,0 +1,49 @@
+package processors
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	. "github.com/patrixr/auteur/core"
+)
+
+var (
+	auteurTagRexp  = regexp.MustCompile(regexp.QuoteMeta(AUTEUR_TAG) + `\(`)
+	auteurArgsRexp = regexp.MustCompile(`^` + regexp.QuoteMeta(AUTEUR_TAG) + `\(` + auteurArgsPattern + `\)`)
+	quotedRexp     = regexp.MustCompile(`"[^"\n]*"`)
+)
+
+// lintAuteurTags reports the @auteur(...) tags of a comment whose arguments can't be parsed,
+// such comments are silently left out of the site
+func lintAuteurTags(file string, comment commentBlock) []Problem {
+	problems := []Problem{}
+
+	for _, loc := range auteurTagRexp.FindAllStringIndex(comment.Text, -1) {
+		line := comment.StartLine + strings.Count(comment.Text[:loc[0]], "\n")
+		match := auteurArgsRexp.FindStringSubmatch(comment.Text[loc[0]:])
+
+		if match == nil {
+			problems = append(problems, Problem{
+				Severity: SeverityError,
+				Rule:     "auteur-args",
+				File:     file,
+				Line:     line,
+				Message:  fmt.Sprintf(`Malformed %s(...) arguments, expected a quoted path e.g %s("guides/setup")`, AUTEUR_TAG, AUTEUR_TAG),
+			})
+			continue
+		}
+
+		if args := quotedRexp.FindAllString(match[1], -1); len(args) > 1 {
+			problems = append(problems, Problem{
+				Severity: SeverityWarning,
+				Rule:     "auteur-args",
+				File:     file,
+				Line:     line,
+				Message:  fmt.Sprintf("Only the first argument of %s(...) is used, %d were given", AUTEUR_TAG, len(args)),
+			})
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,65 @@
+package processors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/patrixr/auteur/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	rootdir := t.TempDir()
+
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+	site.Rootdir = rootdir
+
+	lint := func(processor Processor, name string, data string) []Problem {
+		file := filepath.Join(rootdir, name)
+		os.WriteFile(file, []byte(data), 0644)
+		return processor.(LintingProcessor).Lint(site, file)
+	}
+
+	t.Run("Valid frontmatter has no problems", func(t *testing.T) {
+		problems := lint(NewMarkdownProcessor(), "valid.md", "---\ntitle: Setup\npriority: 2\ndate: 2025-01-01\n---\n# Setup")
+		assert.Empty(t, problems)
+	})
+
+	t.Run("Frontmatter of the wrong type is an error", func(t *testing.T) {
+		problems := lint(NewMarkdownProcessor(), "invalid.md", "---\npriority: high\n---\n# Setup")
+		assert.Len(t, problems, 1)
+		assert.Equal(t, SeverityError, problems[0].Severity)
+		assert.Equal(t, "frontmatter", problems[0].Rule)
+	})
+
+	t.Run("Unknown frontmatter keys are reported", func(t *testing.T) {
+		problems := lint(NewMarkdownProcessor(), "unknown.md", "---\ntitle: Setup\nsidebar: left\n---\n# Setup")
+		assert.Len(t, problems, 1)
+		assert.Equal(t, SeverityWarning, problems[0].Severity)
+		assert.Equal(t, 3, problems[0].Line)
+		assert.Contains(t, problems[0].Message, `"sidebar"`)
+	})
+
+	t.Run("Malformed tag arguments are reported on their line", func(t *testing.T) {
+		source := "package main\n\n// @auteur(guide)\n// # Guide\nfunc main() {}\n\n/*\n@auteur(\"guide\", \"other\")\n# Other\n*/\n"
+		problems := lint(NewCommentReader(), "main.go", source)
+
+		assert.Len(t, problems, 2)
+		assert.Contains(t, problems, Problem{
+			Severity: SeverityError,
+			Rule:     "auteur-args",
+			File:     filepath.Join(rootdir, "main.go"),
+			Line:     3,
+			Message:  `Malformed @auteur(...) arguments, expected a quoted path e.g @auteur("guides/setup")`,
+		})
+		assert.Contains(t, problems, Problem{
+			Severity: SeverityWarning,
+			Rule:     "auteur-args",
+			File:     filepath.Join(rootdir, "main.go"),
+			Line:     8,
+			Message:  "Only the first argument of @auteur(...) is used, 2 were given",
+		})
+	})
+}
@@ -0,0 +1,36 @@
+package processors
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	. "github.com/patrixr/auteur/core"
+)
+
+// fileLocale returns the locale marker of a file name, e.g "fr" for setup.fr.md,
+// when it is one of the locales of the site
+func fileLocale(site *Auteur, file string) string {
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	parts := strings.Split(name, ".")
+
+	if len(parts) < 2 {
+		return ""
+	}
+
+	marker := strings.ToLower(parts[len(parts)-1])
+	if slices.Contains(site.Locales, marker) {
+		return marker
+	}
+
+	return ""
+}
+
+// contentLocale returns the locale of a chunk of content, the lang frontmatter
+// field takes precedence over the locale marker of the file name
+func contentLocale(site *Auteur, file string, fm AuteurFrontmatter) string {
+	if fm.Lang != "" {
+		return strings.ToLower(fm.Lang)
+	}
+	return fileLocale(site, file)
+}
@@ -0,0 +1,47 @@
+package processors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/patrixr/auteur/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleMarkers(t *testing.T) {
+	rootdir := t.TempDir()
+
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+	site.Rootdir = rootdir
+	site.Locales = []string{"en", "fr"}
+
+	processor := NewMarkdownProcessor()
+
+	load := func(name string, data string) Content {
+		file := filepath.Join(rootdir, name)
+		os.WriteFile(file, []byte(data), 0644)
+
+		contents, err := processor.Load(site, file)
+		assert.NoError(t, err)
+		assert.Len(t, contents, 1)
+		return contents[0]
+	}
+
+	t.Run("Locale is read from the file name", func(t *testing.T) {
+		content := load("setup.fr.md", "# Installation")
+		assert.Equal(t, "fr", content.Locale())
+		assert.Equal(t, []string{"setup"}, content.Path())
+	})
+
+	t.Run("Lang frontmatter takes precedence", func(t *testing.T) {
+		content := load("guide.md", "---\nlang: FR\n---\n# Guide")
+		assert.Equal(t, "fr", content.Locale())
+	})
+
+	t.Run("Unknown markers are ignored", func(t *testing.T) {
+		content := load("notes.v2.md", "# Notes")
+		assert.Equal(t, "", content.Locale())
+	})
+}
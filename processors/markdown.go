@@ -1,6 +1,7 @@
 package processors
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,13 +11,6 @@ import (
 	"github.com/patrixr/q"
 )
 
-type AuteurFrontmatter struct {
-	Path     string `yaml:"path"`
-	Title    string `yaml:"title"`
-	Priority int    `yaml:"priority"`
-	Ignore   bool   `yaml:"ignore"`
-}
-
 type MarkdownProcessor struct {
 	folder string
 }
@@ -40,7 +34,7 @@ func (r *MarkdownProcessor) Load(site *Auteur, file string) ([]Content, error) {
 	title := strings.Split(filepath.Base(file), ".")[0]
 	title = strings.ReplaceAll(title, "_", " ")
 	title = strings.ReplaceAll(title, "-", " ")
-	relPath, err := site.GetRelativePath(file)
+	relPath, err := site.SourcePath(file)
 	if err != nil {
 		return []Content{}, err
 	}
@@ -58,12 +52,12 @@ func (r *MarkdownProcessor) Load(site *Auteur, file string) ([]Content, error) {
 		path[len(path)-1] = filename
 	}
 
-	meta, html, err := MarkdownToHTMLWithMeta([]byte(content))
+	meta, html, err := MarkdownFileToHTMLWithMeta([]byte(content), file)
 	if err != nil {
 		return []Content{}, err
 	}
 
-	fm, err := MetaToStruct[AuteurFrontmatter](meta)
+	fm, meta, err := parseFrontmatter(site, meta, file, string(content), 1)
 	if err != nil {
 		return []Content{}, err
 	}
@@ -88,6 +82,30 @@ func (r *MarkdownProcessor) Load(site *Auteur, file string) ([]Content, error) {
 			title:    title,
 			path:     path,
 			priority: fm.Priority,
+			locale:   contentLocale(site, file, fm),
+			origin:   Origin{File: file, StartLine: 1, EndLine: lineCount(content)},
 		},
 	}, nil
 }
+
+// Lint Reports the frontmatter problems of the file
+func (r *MarkdownProcessor) Lint(site *Auteur, file string) []Problem {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return []Problem{{Severity: SeverityError, Rule: "load", File: file, Message: err.Error()}}
+	}
+
+	meta, _, err := MarkdownFileToHTMLWithMeta(content, file)
+	if err != nil {
+		return []Problem{{
+			Severity: SeverityError,
+			Rule:     "frontmatter",
+			File:     file,
+			Line:     1,
+			Message:  fmt.Sprintf("Invalid frontmatter: %s", err),
+		}}
+	}
+
+	_, _, problems := ParseFrontmatter(site, meta, file, string(content), 1)
+	return problems
+}
@@ -0,0 +1,471 @@
+package processors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+	"gopkg.in/yaml.v3"
+)
+
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Maximum depth of nested $ref resolved when rendering schemas
+const openAPIMaxRefDepth = 8
+
+// OpenAPIProcessor renders OpenAPI 3 documents (YAML or JSON) into an overview page
+// and one page per operation, placed under the path configured in `openapi.path`
+type OpenAPIProcessor struct{}
+
+func NewOpenAPIProcessor() Processor {
+	return &OpenAPIProcessor{}
+}
+
+func (r *OpenAPIProcessor) Supports(extension string) bool {
+	return extension == ".yaml" || extension == ".yml" || extension == ".json"
+}
+
+func (r *OpenAPIProcessor) Load(site *Auteur, file string) ([]Content, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return []Content{}, err
+	}
+
+	// Cheap check to avoid parsing every configuration file of the project
+	if !bytes.Contains(data, []byte("openapi")) {
+		return []Content{}, nil
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		// Not a valid document, most likely not an OpenAPI spec either
+		return []Content{}, nil
+	}
+
+	spec, _ := stringKeys(raw).(map[string]any)
+
+	// Unquoted versions such as 3.1 are decoded as numbers
+	version := ""
+	switch value := spec["openapi"].(type) {
+	case nil:
+		return []Content{}, nil
+	case string:
+		version = value
+	case int, float64:
+		version = fmt.Sprint(value)
+	}
+
+	if !strings.HasPrefix(version, "3") {
+		LogWarn("Skipping OpenAPI document, only version 3 is supported", "file", file, "openapi", spec["openapi"])
+		return []Content{}, nil
+	}
+
+	Logf("Reading OpenAPI document %s", file)
+
+	doc := &openAPIDocument{spec: spec}
+
+	title := doc.str(doc.info(), "title")
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+
+	section := []string{}
+	for _, part := range strings.Split(site.OpenAPI.Path, "/") {
+		if strings.TrimSpace(part) != "" {
+			section = append(section, part)
+		}
+	}
+	section = append(section, title)
+
+	overview, err := MarkdownToHTML(doc.overviewMarkdown(title))
+	if err != nil {
+		return []Content{}, err
+	}
+
+	out := []Content{
+		&ContentData{
+			metadata: Metadata{"openapi": version},
+			data:     overview,
+			path:     section,
+			kind:     HTML,
+			title:    title,
+			origin:   Origin{File: file},
+		},
+	}
+
+	for i, op := range doc.operations() {
+		html, err := MarkdownToHTML(doc.operationMarkdown(op))
+		if err != nil {
+			return out, err
+		}
+
+		out = append(out, &ContentData{
+			metadata: Metadata{"method": op.Method, "path": op.Path, "operationId": doc.str(op.Spec, "operationId")},
+			data:     html,
+			path:     append(append([]string{}, section...), op.Title()),
+			kind:     HTML,
+			title:    op.Title(),
+			origin:   Origin{File: file},
+			// Decreasing priorities keep the operations in the order of the document
+			priority: -(i + 1),
+		})
+	}
+
+	return out, nil
+}
+
+// -----------------------------------
+// Helpers
+// -----------------------------------
+
+type openAPIDocument struct {
+	spec map[string]any
+}
+
+type openAPIOperation struct {
+	Method string
+	Path   string
+	Spec   map[string]any
+	// Parameters shared by all the operations of the path
+	Shared []any
+}
+
+func (op openAPIOperation) Title() string {
+	return fmt.Sprintf("%s %s", strings.ToUpper(op.Method), op.Path)
+}
+
+func (doc *openAPIDocument) info() map[string]any {
+	return doc.obj(doc.spec, "info")
+}
+
+// operations returns every operation of the document, sorted by path then method
+func (doc *openAPIDocument) operations() []openAPIOperation {
+	paths := doc.obj(doc.spec, "paths")
+
+	keys := make([]string, 0, len(paths))
+	for key := range paths {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	ops := []openAPIOperation{}
+
+	for _, path := range keys {
+		item := doc.resolve(paths[path], 0)
+		itemMap, _ := item.(map[string]any)
+
+		shared, _ := itemMap["parameters"].([]any)
+
+		for _, method := range openAPIMethods {
+			spec, ok := doc.resolve(itemMap[method], 0).(map[string]any)
+			if !ok {
+				continue
+			}
+
+			ops = append(ops, openAPIOperation{
+				Method: method,
+				Path:   path,
+				Spec:   spec,
+				Shared: shared,
+			})
+		}
+	}
+
+	return ops
+}
+
+func (doc *openAPIDocument) overviewMarkdown(title string) []byte {
+	var sb bytes.Buffer
+	info := doc.info()
+
+	fmt.Fprintf(&sb, "# %s\n\n", title)
+
+	if version := doc.str(info, "version"); version != "" {
+		fmt.Fprintf(&sb, "Version `%s`\n\n", version)
+	}
+
+	if desc := doc.str(info, "description"); desc != "" {
+		fmt.Fprintf(&sb, "%s\n\n", desc)
+	}
+
+	if servers, ok := doc.spec["servers"].([]any); ok && len(servers) > 0 {
+		sb.WriteString("## Servers\n\n")
+		for _, server := range servers {
+			serverMap, _ := server.(map[string]any)
+			line := fmt.Sprintf("- `%s`", doc.str(serverMap, "url"))
+			if desc := doc.str(serverMap, "description"); desc != "" {
+				line += " " + desc
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	ops := doc.operations()
+	if len(ops) > 0 {
+		sb.WriteString("## Operations\n\n")
+		sb.WriteString("| Method | Path | Summary |\n")
+		sb.WriteString("| ------ | ---- | ------- |\n")
+		for _, op := range ops {
+			fmt.Fprintf(&sb, "| `%s` | `%s` | %s |\n", strings.ToUpper(op.Method), op.Path, cell(doc.str(op.Spec, "summary")))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.Bytes()
+}
+
+func (doc *openAPIDocument) operationMarkdown(op openAPIOperation) []byte {
+	var sb bytes.Buffer
+
+	fmt.Fprintf(&sb, "# %s\n\n", op.Title())
+
+	if doc.bool(op.Spec, "deprecated") {
+		sb.WriteString("> **Deprecated**\n\n")
+	}
+
+	if summary := doc.str(op.Spec, "summary"); summary != "" {
+		fmt.Fprintf(&sb, "**%s**\n\n", summary)
+	}
+
+	if desc := doc.str(op.Spec, "description"); desc != "" {
+		fmt.Fprintf(&sb, "%s\n\n", desc)
+	}
+
+	if id := doc.str(op.Spec, "operationId"); id != "" {
+		fmt.Fprintf(&sb, "Operation ID: `%s`\n\n", id)
+	}
+
+	// Parameters
+	params, _ := op.Spec["parameters"].([]any)
+	params = append(append([]any{}, op.Shared...), params...)
+
+	if len(params) > 0 {
+		sb.WriteString("## Parameters\n\n")
+		sb.WriteString("| Name | In | Type | Required | Description |\n")
+		sb.WriteString("| ---- | -- | ---- | -------- | ----------- |\n")
+		for _, param := range params {
+			paramMap, _ := doc.resolve(param, 0).(map[string]any)
+			schema, _ := doc.resolve(paramMap["schema"], 0).(map[string]any)
+			required := "no"
+			if doc.bool(paramMap, "required") {
+				required = "yes"
+			}
+
+			fmt.Fprintf(&sb, "| `%s` | %s | %s | %s | %s |\n",
+				doc.str(paramMap, "name"),
+				doc.str(paramMap, "in"),
+				cell(doc.str(schema, "type")),
+				required,
+				cell(doc.str(paramMap, "description")),
+			)
+		}
+		sb.WriteString("\n")
+	}
+
+	// Request body
+	if body, ok := doc.resolve(op.Spec["requestBody"], 0).(map[string]any); ok {
+		sb.WriteString("## Request Body\n\n")
+		if desc := doc.str(body, "description"); desc != "" {
+			fmt.Fprintf(&sb, "%s\n\n", desc)
+		}
+		doc.writeContent(&sb, doc.obj(body, "content"))
+	}
+
+	// Responses
+	responses := doc.obj(op.Spec, "responses")
+	if len(responses) > 0 {
+		sb.WriteString("## Responses\n\n")
+
+		codes := make([]string, 0, len(responses))
+		for code := range responses {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+
+		for _, code := range codes {
+			response, _ := doc.resolve(responses[code], 0).(map[string]any)
+			fmt.Fprintf(&sb, "### %s\n\n", code)
+			if desc := doc.str(response, "description"); desc != "" {
+				fmt.Fprintf(&sb, "%s\n\n", desc)
+			}
+			doc.writeContent(&sb, doc.obj(response, "content"))
+		}
+	}
+
+	return sb.Bytes()
+}
+
+// writeContent renders the schema and examples of every media type of a request or response
+func (doc *openAPIDocument) writeContent(sb *bytes.Buffer, content map[string]any) {
+	mediaTypes := make([]string, 0, len(content))
+	for mediaType := range content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+
+	for _, mediaType := range mediaTypes {
+		media, _ := content[mediaType].(map[string]any)
+
+		fmt.Fprintf(sb, "`%s`\n\n", mediaType)
+
+		if schema, ok := media["schema"]; ok {
+			sb.WriteString("Schema:\n\n")
+			writeCodeBlock(sb, "yaml", doc.resolve(schema, 0))
+		}
+
+		if example, ok := media["example"]; ok {
+			sb.WriteString("Example:\n\n")
+			writeCodeBlock(sb, "json", example)
+		}
+
+		examples := doc.obj(media, "examples")
+		names := make([]string, 0, len(examples))
+		for name := range examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			example, _ := doc.resolve(examples[name], 0).(map[string]any)
+			fmt.Fprintf(sb, "Example `%s`:\n\n", name)
+			if summary := doc.str(example, "summary"); summary != "" {
+				fmt.Fprintf(sb, "%s\n\n", summary)
+			}
+			writeCodeBlock(sb, "json", example["value"])
+		}
+	}
+}
+
+// resolve replaces local references (#/components/...) with the object they point at
+func (doc *openAPIDocument) resolve(value any, depth int) any {
+	if depth > openAPIMaxRefDepth {
+		return value
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok {
+			target := doc.lookup(ref)
+			if target == nil {
+				return v
+			}
+			return doc.resolve(target, depth+1)
+		}
+
+		resolved := make(map[string]any, len(v))
+		for key, child := range v {
+			resolved[key] = doc.resolve(child, depth)
+		}
+		return resolved
+
+	case []any:
+		resolved := make([]any, len(v))
+		for i, child := range v {
+			resolved[i] = doc.resolve(child, depth)
+		}
+		return resolved
+	}
+
+	return value
+}
+
+func (doc *openAPIDocument) lookup(ref string) any {
+	path, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil
+	}
+
+	var current any = doc.spec
+	for _, part := range strings.Split(path, "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		currentMap, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = currentMap[part]
+	}
+
+	return current
+}
+
+func (doc *openAPIDocument) obj(m map[string]any, key string) map[string]any {
+	if m == nil {
+		return map[string]any{}
+	}
+	value, _ := doc.resolve(m[key], 0).(map[string]any)
+	if value == nil {
+		return map[string]any{}
+	}
+	return value
+}
+
+func (doc *openAPIDocument) str(m map[string]any, key string) string {
+	if m == nil {
+		return ""
+	}
+	value, _ := m[key].(string)
+	return value
+}
+
+func (doc *openAPIDocument) bool(m map[string]any, key string) bool {
+	if m == nil {
+		return false
+	}
+	value, _ := m[key].(bool)
+	return value
+}
+
+// stringKeys converts the mappings decoded by yaml to maps keyed by strings.
+// Mappings with non-string keys, e.g unquoted status codes, are decoded as map[any]any
+func stringKeys(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, item := range value {
+			value[key] = stringKeys(item)
+		}
+		return value
+	case map[any]any:
+		converted := make(map[string]any, len(value))
+		for key, item := range value {
+			converted[fmt.Sprint(key)] = stringKeys(item)
+		}
+		return converted
+	case []any:
+		for i, item := range value {
+			value[i] = stringKeys(item)
+		}
+		return value
+	}
+
+	return value
+}
+
+func writeCodeBlock(sb *bytes.Buffer, lang string, value any) {
+	var text []byte
+	var err error
+
+	if lang == "json" {
+		text, err = json.MarshalIndent(value, "", "  ")
+	} else {
+		text, err = yaml.Marshal(value)
+	}
+
+	if err != nil {
+		text = []byte(fmt.Sprintf("%v", value))
+	}
+
+	fmt.Fprintf(sb, "```%s\n%s\n```\n\n", lang, strings.TrimRight(string(text), "\n"))
+}
+
+// cell escapes text for use inside of a markdown table cell
+func cell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.ReplaceAll(text, "\n", " ")
+}
@@ -0,0 +1,129 @@
+package processors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/patrixr/auteur/core"
+	"github.com/patrixr/q"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPIProcessor(t *testing.T) {
+	tmpdir := t.TempDir()
+
+	spec := q.Paragraph(`
+		openapi: 3.0.3
+		info:
+		  title: Pets
+		  version: 1.2.0
+		  description: Manage pets
+		paths:
+		  /pets/{id}:
+		    parameters:
+		      - name: id
+		        in: path
+		        required: true
+		        schema:
+		          type: string
+		    get:
+		      summary: Get a pet
+		      operationId: getPet
+		      responses:
+		        "200":
+		          description: The pet
+		          content:
+		            application/json:
+		              schema:
+		                $ref: "#/components/schemas/Pet"
+		              example:
+		                name: Rex
+		  /pets:
+		    post:
+		      summary: Create a pet
+		      requestBody:
+		        content:
+		          application/json:
+		            schema:
+		              $ref: "#/components/schemas/Pet"
+		      responses:
+		        "201":
+		          description: Created
+		components:
+		  schemas:
+		    Pet:
+		      type: object
+		      properties:
+		        name:
+		          type: string
+	`)
+
+	specFile := filepath.Join(tmpdir, "pets.yaml")
+	os.WriteFile(specFile, []byte(spec), 0644)
+
+	otherFile := filepath.Join(tmpdir, "config.yaml")
+	os.WriteFile(otherFile, []byte("title: not an api\n"), 0644)
+
+	site, err := NewAuteur()
+	assert.NoError(t, err)
+
+	processor := NewOpenAPIProcessor()
+
+	t.Run("Ignores non OpenAPI documents", func(t *testing.T) {
+		got, err := processor.Load(site, otherFile)
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("Renders an overview and a page per operation", func(t *testing.T) {
+		got, err := processor.Load(site, specFile)
+		assert.NoError(t, err)
+		assert.Len(t, got, 3)
+
+		overview := got[0]
+		assert.Equal(t, []string{"api", "Pets"}, overview.Path())
+		assert.Contains(t, overview.Data(), "Manage pets")
+		assert.Contains(t, overview.Data(), "Create a pet")
+
+		post := got[1]
+		assert.Equal(t, []string{"api", "Pets", "POST /pets"}, post.Path())
+		assert.Contains(t, post.Data(), "Request Body")
+		assert.Contains(t, textOf(post.Data()), "name:")
+
+		get := got[2]
+		assert.Equal(t, []string{"api", "Pets", "GET /pets/{id}"}, get.Path())
+		assert.Contains(t, get.Data(), "<code>id</code>")
+		assert.Contains(t, get.Data(), "getPet")
+		assert.Contains(t, get.Data(), "Rex")
+		assert.Greater(t, post.Priority(), get.Priority())
+	})
+
+	t.Run("Unquoted status codes and versions are supported", func(t *testing.T) {
+		file := filepath.Join(tmpdir, "status.yaml")
+		os.WriteFile(file, []byte(q.Paragraph(`
+			openapi: 3.1
+			info:
+			  title: Status
+			paths:
+			  /status:
+			    get:
+			      summary: Get the status
+			      responses:
+			        200:
+			          description: Everything is fine
+			        '404':
+			          description: Nothing here
+		`)), 0644)
+
+		got, err := processor.Load(site, file)
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, "3.1", got[0].Meta()["openapi"])
+
+		get := got[1].Data()
+		assert.Contains(t, get, "Responses")
+		assert.Contains(t, get, "Everything is fine")
+		assert.Contains(t, get, "Nothing here")
+	})
+}
@@ -0,0 +1,37 @@
+package processors
+
+import (
+	"fmt"
+	"sort"
+
+	. "github.com/patrixr/auteur/core"
+)
+
+// Processors available to the configuration, by name
+var registry = map[string]func() Processor{
+	"comments": NewCommentReader,
+	"markdown": NewMarkdownProcessor,
+	"godoc":    NewGoDocProcessor,
+	"openapi":  NewOpenAPIProcessor,
+}
+
+// NewProcessor creates a processor from its name, as used in the configuration
+func NewProcessor(name string) (Processor, error) {
+	constructor, ok := registry[name]
+
+	if !ok {
+		return nil, fmt.Errorf("unknown processor %q, available processors are %v", name, ProcessorNames())
+	}
+
+	return constructor(), nil
+}
+
+// ProcessorNames returns the names of all available processors
+func ProcessorNames() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const reloadEndpoint = "/__auteur/reload"
+
+const reloadScript = `<script>
+  (function () {
+    const source = new EventSource("%s");
+    source.addEventListener("reload", () => window.location.reload());
+  })();
+</script>
+`
+
+// Server serves a rendered site from its output folder and notifies
+// connected browsers whenever the site has been rebuilt
+type Server struct {
+	outfolder string
+	webroot   string
+	mutex     sync.Mutex
+	clients   map[chan struct{}]struct{}
+}
+
+func NewServer(outfolder string, webroot string) *Server {
+	webroot = "/" + strings.Trim(webroot, "/")
+
+	return &Server{
+		outfolder: outfolder,
+		webroot:   webroot,
+		clients:   map[chan struct{}]struct{}{},
+	}
+}
+
+// Handler returns the http handler serving the site under its webroot
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	prefix := strings.TrimRight(s.webroot, "/")
+
+	mux.HandleFunc(prefix+reloadEndpoint, s.serveEvents)
+	mux.HandleFunc(prefix+"/", s.serveFile)
+
+	if prefix != "" {
+		mux.Handle("/", http.RedirectHandler(prefix+"/", http.StatusFound))
+	}
+
+	return mux
+}
+
+// Reload notifies every connected browser that the site has changed
+func (s *Server) Reload() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for client := range s.clients {
+		select {
+		case client <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	flusher.Flush()
+
+	client := make(chan struct{}, 1)
+
+	s.mutex.Lock()
+	s.clients[client] = struct{}{}
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		delete(s.clients, client)
+		s.mutex.Unlock()
+	}()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-client:
+			fmt.Fprint(w, "event: reload\ndata: {}\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *Server) serveFile(w http.ResponseWriter, r *http.Request) {
+	rel := strings.TrimPrefix(r.URL.Path, strings.TrimRight(s.webroot, "/"))
+	file, ok := s.resolve(rel)
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if filepath.Ext(file) != ".html" {
+		http.ServeFile(w, r, file)
+		return
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(s.injectReloadScript(content))
+}
+
+// resolve maps a url path onto a file of the output folder, following the
+// same conventions as the builder: /page -> page.html, /folder -> folder/index.html
+func (s *Server) resolve(urlPath string) (string, bool) {
+	base := filepath.Join(s.outfolder, filepath.FromSlash(path.Clean("/"+urlPath)))
+
+	candidates := []string{
+		base,
+		base + ".html",
+		filepath.Join(base, "index.html"),
+	}
+
+	for _, candidate := range candidates {
+		info, err := os.Stat(candidate)
+		if err == nil && !info.IsDir() {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
+
+func (s *Server) injectReloadScript(html []byte) []byte {
+	idx := bytes.LastIndex(html, []byte("</body>"))
+	if idx < 0 {
+		return html
+	}
+
+	endpoint := strings.TrimRight(s.webroot, "/") + reloadEndpoint
+	script := fmt.Sprintf(reloadScript, endpoint)
+
+	out := make([]byte, 0, len(html)+len(script))
+	out = append(out, html[:idx]...)
+	out = append(out, script...)
+	out = append(out, html[idx:]...)
+	return out
+}
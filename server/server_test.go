@@ -0,0 +1,110 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	outfolder := t.TempDir()
+
+	files := map[string]string{
+		"index.html":              "<html><body>Home</body></html>",
+		"about.html":              "<html><body>About</body></html>",
+		"guides/index.html":       "<html><body>Guides</body></html>",
+		"guides/setup.html":       "<html><body>Setup</body></html>",
+		"guides/setup.frag.html":  "<h1>Setup</h1>",
+		"style.css":               "body {}",
+		"guides/empty/readme.txt": "",
+	}
+
+	for file, content := range files {
+		os.MkdirAll(filepath.Join(outfolder, filepath.Dir(file)), 0755)
+		os.WriteFile(filepath.Join(outfolder, file), []byte(content), 0644)
+	}
+
+	t.Run("Url paths are resolved to the files of the output folder", func(t *testing.T) {
+		server := NewServer(outfolder, "/")
+
+		tests := []struct {
+			path string
+			file string
+		}{
+			{"/", "index.html"},
+			{"/about", "about.html"},
+			{"/guides", "guides/index.html"},
+			{"/guides/", "guides/index.html"},
+			{"/guides/setup", "guides/setup.html"},
+			{"/guides/setup.frag.html", "guides/setup.frag.html"},
+			{"/style.css", "style.css"},
+			{"/guides/../about", "about.html"},
+			{"//guides/./setup", "guides/setup.html"},
+			{"/../../about", "about.html"},
+			{"/missing", ""},
+			{"/guides/empty", ""},
+		}
+
+		for _, test := range tests {
+			file, ok := server.resolve(test.path)
+
+			if test.file == "" {
+				assert.False(t, ok, test.path)
+				continue
+			}
+
+			assert.True(t, ok, test.path)
+			assert.Equal(t, filepath.Join(outfolder, filepath.FromSlash(test.file)), file, test.path)
+		}
+	})
+
+	t.Run("Sites are served under their webroot", func(t *testing.T) {
+		handler := NewServer(outfolder, "/docs/").Handler()
+
+		tests := []struct {
+			path     string
+			status   int
+			body     string
+			location string
+		}{
+			{"/docs/", http.StatusOK, "Home", ""},
+			{"/docs/guides/setup", http.StatusOK, "Setup", ""},
+			{"/docs/missing", http.StatusNotFound, "", ""},
+			{"/", http.StatusFound, "", "/docs/"},
+			{"/guides/setup", http.StatusFound, "", "/docs/"},
+		}
+
+		for _, test := range tests {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+			assert.Equal(t, test.status, recorder.Code, test.path)
+			assert.Contains(t, recorder.Body.String(), test.body, test.path)
+			assert.Equal(t, test.location, recorder.Header().Get("Location"), test.path)
+		}
+	})
+
+	t.Run("Pages load the reload script", func(t *testing.T) {
+		server := NewServer(outfolder, "/docs")
+
+		html := string(server.injectReloadScript([]byte("<html><body><p>Page</p></body></html>")))
+		assert.Contains(t, html, `new EventSource("/docs/__auteur/reload")`)
+		assert.Regexp(t, `<p>Page</p><script>[\s\S]*</script>\n</body></html>$`, html)
+
+		fragment := "<h1>Setup</h1>"
+		assert.Equal(t, fragment, string(server.injectReloadScript([]byte(fragment))))
+
+		recorder := httptest.NewRecorder()
+		server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/docs/about", nil))
+		assert.Contains(t, recorder.Body.String(), reloadEndpoint)
+		assert.Equal(t, "no-cache", recorder.Header().Get("Cache-Control"))
+
+		recorder = httptest.NewRecorder()
+		server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/docs/style.css", nil))
+		assert.NotContains(t, recorder.Body.String(), reloadEndpoint)
+	})
+}
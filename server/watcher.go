@@ -0,0 +1,125 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	. "github.com/patrixr/auteur/common"
+	. "github.com/patrixr/auteur/core"
+)
+
+// Watcher recursively watches a folder for changes, ignoring excluded paths
+type Watcher struct {
+	root      string
+	exclusion *Exclusion
+	ignored   []string
+	delay     time.Duration
+	fsnotify  *fsnotify.Watcher
+}
+
+// NewWatcher creates a watcher for the root folder. Files left out by the exclusion
+// are skipped, as are the ignored folders (e.g the output folder)
+func NewWatcher(root string, exclusion *Exclusion, ignored ...string) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{
+		root:      root,
+		exclusion: exclusion,
+		ignored:   ignored,
+		delay:     200 * time.Millisecond,
+		fsnotify:  fsw,
+	}
+
+	if err := w.add(root); err != nil {
+		fsw.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Watch blocks and calls onChange every time a batch of changes is detected.
+// Events are debounced so that saving multiple files only triggers a single call
+func (w *Watcher) Watch(onChange func()) error {
+	var timer *time.Timer
+
+	for {
+		select {
+		case event, ok := <-w.fsnotify.Events:
+			if !ok {
+				return nil
+			}
+
+			if w.skip(event.Name) {
+				continue
+			}
+
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := w.add(event.Name); err != nil {
+						LogWarn("Unable to watch folder", "path", event.Name, "err", err)
+					}
+				}
+			}
+
+			LogDebug("Change detected", "path", event.Name, "op", event.Op.String())
+
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(w.delay, onChange)
+
+		case err, ok := <-w.fsnotify.Errors:
+			if !ok {
+				return nil
+			}
+			LogError(err)
+		}
+	}
+}
+
+func (w *Watcher) Close() error {
+	return w.fsnotify.Close()
+}
+
+func (w *Watcher) add(folder string) error {
+	return filepath.WalkDir(folder, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !entry.IsDir() {
+			return nil
+		}
+
+		if path != w.root && w.skip(path) {
+			return filepath.SkipDir
+		}
+
+		return w.fsnotify.Add(path)
+	})
+}
+
+func (w *Watcher) skip(path string) bool {
+	for _, ignored := range w.ignored {
+		if path == ignored || strings.HasPrefix(path, ignored+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	if _, err := filepath.Rel(w.root, path); err != nil {
+		return true
+	}
+
+	// Removed paths are treated as folders, so that their removal isn't missed
+	info, err := os.Stat(path)
+	isDir := err != nil || info.IsDir()
+
+	return w.exclusion.ExcludedPath(path, isDir)
+}
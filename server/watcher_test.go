@@ -0,0 +1,57 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	. "github.com/patrixr/auteur/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcher(t *testing.T) {
+	root := t.TempDir()
+	outfolder := filepath.Join(root, "dist")
+
+	for _, folder := range []string{"docs", "drafts", "node_modules/lib", "dist/guides"} {
+		os.MkdirAll(filepath.Join(root, folder), 0755)
+	}
+
+	exclusion := NewExclusion(root, append(slices.Clone(DefaultExclude), "drafts", "*.bak"), nil, false)
+
+	watcher, err := NewWatcher(root, exclusion, outfolder)
+	assert.NoError(t, err)
+	defer watcher.Close()
+
+	tests := []struct {
+		path string
+		skip bool
+	}{
+		{"docs", false},
+		{"docs/intro.md", false},
+		{"docs/removed", false},
+		{"drafts", true},
+		{"drafts/wip.md", true},
+		{"docs/intro.md.bak", true},
+		{"node_modules/lib", true},
+		{"dist", true},
+		{"dist/guides/index.html", true},
+		{"distribution.md", false},
+	}
+
+	os.WriteFile(filepath.Join(root, "docs", "intro.md"), nil, 0644)
+
+	for _, test := range tests {
+		assert.Equal(t, test.skip, watcher.skip(filepath.Join(root, filepath.FromSlash(test.path))), test.path)
+	}
+
+	t.Run("Excluded folders aren't watched", func(t *testing.T) {
+		watched := watcher.fsnotify.WatchList()
+
+		assert.Contains(t, watched, filepath.Join(root, "docs"))
+		assert.NotContains(t, watched, filepath.Join(root, "drafts"))
+		assert.NotContains(t, watched, filepath.Join(root, "node_modules", "lib"))
+		assert.NotContains(t, watched, filepath.Join(root, "dist", "guides"))
+	})
+}
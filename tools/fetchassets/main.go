@@ -0,0 +1,25 @@
+// fetchassets downloads the third-party assets used by the default theme
+// into builder/assets/thirdparty so that they get embedded in the binary
+package main
+
+import (
+	"os"
+
+	"github.com/patrixr/auteur/builder"
+	. "github.com/patrixr/auteur/common"
+)
+
+func main() {
+	dest := "builder/assets/thirdparty"
+
+	if len(os.Args) > 1 {
+		dest = os.Args[1]
+	}
+
+	if err := builder.DownloadVendorAssets(dest); err != nil {
+		LogError(err)
+		os.Exit(1)
+	}
+
+	Log("Third-party assets downloaded", "folder", dest)
+}